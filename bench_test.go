@@ -0,0 +1,86 @@
+package mapset_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jimmyfrasche/mapset"
+)
+
+// benchMaps returns a map of size n and a map of size m that share half the keys of the smaller map.
+func benchMaps(n, m int) (map[int]int, map[int]int) {
+	lhs := make(map[int]int, n)
+	for i := 0; i < n; i++ {
+		lhs[i] = i
+	}
+	rhs := make(map[int]int, m)
+	start := min(n, m) / 2
+	for i := start; i < start+m; i++ {
+		rhs[i] = i
+	}
+	return lhs, rhs
+}
+
+var benchSizes = [][2]int{
+	{100, 100},
+	{10000, 10},
+	{10, 10000},
+}
+
+func benchMerge(a, b int) int {
+	return a + b
+}
+
+func bench(b *testing.B, f func(lhs, rhs map[int]int)) {
+	for _, sz := range benchSizes {
+		lhs, rhs := benchMaps(sz[0], sz[1])
+		b.Run(fmt.Sprintf("%dx%d", sz[0], sz[1]), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				f(lhs, rhs)
+			}
+		})
+	}
+}
+
+func BenchmarkUnion(b *testing.B) {
+	bench(b, func(lhs, rhs map[int]int) {
+		mapset.Union(lhs, rhs, nil, benchMerge)
+	})
+}
+
+func BenchmarkUnionLen(b *testing.B) {
+	bench(b, func(lhs, rhs map[int]int) {
+		mapset.UnionLen(lhs, rhs, nil, benchMerge)
+	})
+}
+
+func BenchmarkIntersect(b *testing.B) {
+	bench(b, func(lhs, rhs map[int]int) {
+		mapset.Intersect(lhs, rhs, nil, benchMerge)
+	})
+}
+
+func BenchmarkIntersectLen(b *testing.B) {
+	bench(b, func(lhs, rhs map[int]int) {
+		mapset.IntersectLen(lhs, rhs, nil, benchMerge)
+	})
+}
+
+func BenchmarkDiff(b *testing.B) {
+	bench(b, func(lhs, rhs map[int]int) {
+		mapset.Diff(lhs, rhs, nil)
+	})
+}
+
+func BenchmarkDiffLen(b *testing.B) {
+	bench(b, func(lhs, rhs map[int]int) {
+		mapset.DiffLen(lhs, rhs, nil)
+	})
+}
+
+func BenchmarkDisjoint(b *testing.B) {
+	bench(b, func(lhs, rhs map[int]int) {
+		mapset.Disjoint(lhs, rhs, nil)
+	})
+}
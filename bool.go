@@ -1,13 +1,11 @@
 package mapset
 
+import "iter"
+
 func containsBool(b bool) bool {
 	return b
 }
 
-func mergeBool(lhs, rhs bool) bool {
-	return lhs || rhs
-}
-
 // Bool provides all relevant set methods with identity as the [ContainsFunc] and disjunction as the [MergeFunc].
 type Bool[K comparable] map[K]bool
 
@@ -18,6 +16,13 @@ func (b Bool[K]) Extend(keys ...K) {
 	}
 }
 
+// ExtendSeq adds the keys of seq to b.
+func (b Bool[K]) ExtendSeq(seq iter.Seq[K]) {
+	for k := range seq {
+		b[k] = true
+	}
+}
+
 // Add key and reports whether it is new.
 func (b Bool[K]) Add(key K) bool {
 	seen := b[key]
@@ -40,11 +45,11 @@ func (b Bool[K]) Remove(keys ...K) {
 }
 
 func (b Bool[K]) Union(o Bool[K]) Bool[K] {
-	return Union(b, o, containsBool, mergeBool)
+	return Union(b, o, containsBool, Either)
 }
 
 func (b Bool[K]) Intersect(o Bool[K]) Bool[K] {
-	return Intersect(b, o, containsBool, mergeBool)
+	return Intersect(b, o, containsBool, Either)
 }
 
 func (b Bool[K]) Diff(o Bool[K]) Bool[K] {
@@ -55,6 +60,41 @@ func (b Bool[K]) SymDiff(o Bool[K]) Bool[K] {
 	return SymDiff(b, o, containsBool)
 }
 
+// UnionWith adds the keys of o to b.
+func (b Bool[K]) UnionWith(o Bool[K]) {
+	UnionInto(b, o, containsBool, Either)
+}
+
+// IntersectWith removes the keys of b that are not in o.
+func (b Bool[K]) IntersectWith(o Bool[K]) {
+	IntersectInPlace(b, o, containsBool, Either)
+}
+
+// DiffWith removes the keys of o from b.
+func (b Bool[K]) DiffWith(o Bool[K]) {
+	DiffInPlace(b, o, containsBool)
+}
+
+// SymDiffWith sets b to the symmetric difference of b and o.
+func (b Bool[K]) SymDiffWith(o Bool[K]) {
+	SymDiffInPlace(b, o, containsBool)
+}
+
+// UnionAll returns the union of b and all of os.
+func (b Bool[K]) UnionAll(os ...Bool[K]) Bool[K] {
+	return UnionAll(containsBool, Either, append([]Bool[K]{b}, os...)...)
+}
+
+// IntersectAll returns the intersection of b and all of os.
+func (b Bool[K]) IntersectAll(os ...Bool[K]) Bool[K] {
+	return IntersectAll(containsBool, Either, append([]Bool[K]{b}, os...)...)
+}
+
+// DiffAll returns the keys of b that are in none of os.
+func (b Bool[K]) DiffAll(os ...Bool[K]) Bool[K] {
+	return DiffAll(b, containsBool, os...)
+}
+
 func (b Bool[K]) Contains(k K) bool {
 	return b[k]
 }
@@ -72,7 +112,7 @@ func (b Bool[K]) Disjoint(o Bool[K]) bool {
 }
 
 func (b Bool[K]) ProperSubset(o Bool[K]) bool {
-	return Subset(b, o, containsBool)
+	return ProperSubset(b, o, containsBool)
 }
 
 func (b Bool[K]) Len() int {
@@ -90,3 +130,55 @@ func (b Bool[K]) Keys() []K {
 func (b Bool[K]) Purge() {
 	Purge(b, containsBool)
 }
+
+// All returns an iterator over the keys k of b where b[k] is true.
+func (b Bool[K]) All() iter.Seq[K] {
+	return KeysSeq(b, containsBool)
+}
+
+func (b Bool[K]) Superset(o Bool[K]) bool {
+	return o.Subset(b)
+}
+
+func (b Bool[K]) ProperSuperset(o Bool[K]) bool {
+	return o.ProperSubset(b)
+}
+
+// Values returns true for each key k of b where b[k] is true.
+func (b Bool[K]) Values() []bool {
+	return Values(b, containsBool)
+}
+
+// Any returns an arbitrary key of b or false if b is empty.
+func (b Bool[K]) Any() (K, bool) {
+	for k := range b.All() {
+		return k, true
+	}
+	var zero K
+	return zero, false
+}
+
+// Pop removes and returns an arbitrary key of b or false if b is empty.
+func (b Bool[K]) Pop() (K, bool) {
+	k, ok := b.Any()
+	if ok {
+		delete(b, k)
+	}
+	return k, ok
+}
+
+// Filter returns a new Bool of the keys of b for which keep returns true.
+func (b Bool[K]) Filter(keep func(K) bool) Bool[K] {
+	out := Bool[K]{}
+	for k := range b.All() {
+		if keep(k) {
+			out[k] = b[k]
+		}
+	}
+	return out
+}
+
+// Clear removes all keys from b.
+func (b Bool[K]) Clear() {
+	clear(b)
+}
@@ -0,0 +1,309 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// exampleData is the input and output of the generated examples.
+//
+// The output is worked out by evaluating the contains and merge expressions
+// as constant expressions, so examples can only be generated
+// when the key and value have predeclared underlying types
+// and the expressions only use operators and builtins.
+type exampleData struct {
+	X, Y string // elements of the composite literals of the inputs
+
+	AddKey    string
+	AddValues []string
+	Add       []string
+
+	Union, Intersect, Diff, SymDiff string
+	Len, Purge                      string
+}
+
+// literals returns the source of some distinct constants of type t in increasing order,
+// or nil if there are none to pick from.
+func literals(t *types.Basic, key bool) []string {
+	info := t.Info()
+	switch {
+	case info&types.IsString != 0 && key:
+		return []string{`"a"`, `"b"`, `"c"`, `"d"`}
+	case info&types.IsString != 0:
+		return []string{`""`, `"a"`, `"b"`, `"c"`}
+	case info&(types.IsInteger|types.IsFloat) != 0 && key:
+		return []string{"1", "2", "3", "4"}
+	case info&types.IsInteger != 0:
+		return []string{"0", "1", "2", "3"}
+	case info&types.IsFloat != 0:
+		return []string{"0", "0.5", "1", "2.5"}
+	case info&types.IsBoolean != 0 && !key:
+		return []string{"false", "true"}
+	}
+	return nil
+}
+
+// model is a map of the generated type with the index of a key in the key literals as the key.
+type model map[int]constant.Value
+
+// evaluator evaluates the contains and merge expressions of a config.
+type evaluator struct {
+	c    *config
+	fset *token.FileSet
+	keys []string
+}
+
+// eval evaluates expr converted to type t with the constants vars of the value type in scope.
+func (e *evaluator) eval(t, expr string, vars map[string]constant.Value) (constant.Value, error) {
+	pkg := types.NewPackage(e.c.Package, e.c.Package)
+	for name, v := range vars {
+		pkg.Scope().Insert(types.NewConst(token.NoPos, pkg, name, e.c.valBasic, v))
+	}
+	tv, err := types.Eval(e.fset, pkg, token.NoPos, t+"("+expr+")")
+	if err != nil {
+		return nil, err
+	}
+	if tv.Value == nil {
+		return nil, fmt.Errorf("%s is not a constant expression", expr)
+	}
+	return tv.Value, nil
+}
+
+func (e *evaluator) contains(v constant.Value) (bool, error) {
+	c, err := e.eval("bool", e.c.Contains, map[string]constant.Value{"v": v})
+	if err != nil {
+		return false, err
+	}
+	return constant.BoolVal(c), nil
+}
+
+func (e *evaluator) merge(l, r constant.Value) (constant.Value, error) {
+	return e.eval(e.c.valBasic.Name(), e.c.Merge, map[string]constant.Value{"l": l, "r": r})
+}
+
+// union, intersect, diff, and symDiff mirror the generated methods.
+
+func (e *evaluator) union(x, y model) (model, error) {
+	out := model{}
+	for k := range e.keys {
+		l, lok, err := e.get(x, k)
+		if err != nil {
+			return nil, err
+		}
+		r, rok, err := e.get(y, k)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case lok && rok:
+			v, err := e.merge(l, r)
+			if err != nil {
+				return nil, err
+			}
+			if ok, err := e.contains(v); err != nil {
+				return nil, err
+			} else if ok {
+				out[k] = v
+			}
+		case lok:
+			out[k] = l
+		case rok:
+			out[k] = r
+		}
+	}
+	return out, nil
+}
+
+func (e *evaluator) intersect(x, y model) (model, error) {
+	out := model{}
+	for k := range e.keys {
+		l, lok, err := e.get(x, k)
+		if err != nil {
+			return nil, err
+		}
+		r, rok, err := e.get(y, k)
+		if err != nil {
+			return nil, err
+		}
+		if !lok || !rok {
+			continue
+		}
+		v, err := e.merge(l, r)
+		if err != nil {
+			return nil, err
+		}
+		if ok, err := e.contains(v); err != nil {
+			return nil, err
+		} else if ok {
+			out[k] = v
+		}
+	}
+	return out, nil
+}
+
+// diff returns the items of x that are not in y, and of y that are not in x if sym is set.
+func (e *evaluator) diff(x, y model, sym bool) (model, error) {
+	out := model{}
+	for k := range e.keys {
+		l, lok, err := e.get(x, k)
+		if err != nil {
+			return nil, err
+		}
+		r, rok, err := e.get(y, k)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case lok && !rok:
+			out[k] = l
+		case rok && !lok && sym:
+			out[k] = r
+		}
+	}
+	return out, nil
+}
+
+// get returns m[k] and whether it is in the set.
+func (e *evaluator) get(m model, k int) (constant.Value, bool, error) {
+	v, ok := m[k]
+	if !ok {
+		return nil, false, nil
+	}
+	ok, err := e.contains(v)
+	return v, ok, err
+}
+
+// formatConst returns the value of c as printed by fmt.
+func formatConst(t *types.Basic, c constant.Value) string {
+	info := t.Info()
+	switch {
+	case info&types.IsBoolean != 0:
+		return strconv.FormatBool(constant.BoolVal(c))
+	case info&types.IsString != 0:
+		return constant.StringVal(c)
+	case info&types.IsFloat != 0:
+		if t.Kind() == types.Float32 {
+			f, _ := constant.Float32Val(constant.ToFloat(c))
+			return strconv.FormatFloat(float64(f), 'g', -1, 32)
+		}
+		f, _ := constant.Float64Val(constant.ToFloat(c))
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	return constant.ToInt(c).ExactString()
+}
+
+// print returns m as printed by fmt.
+func (e *evaluator) print(m model) string {
+	var b strings.Builder
+	b.WriteString("map[")
+	sep := ""
+	for k, lit := range e.keys {
+		v, ok := m[k]
+		if !ok {
+			continue
+		}
+		key, err := e.eval(e.c.keyBasic.Name(), lit, nil)
+		if err != nil {
+			panic(err) // the key literals are always valid
+		}
+		fmt.Fprintf(&b, "%s%s:%s", sep, formatConst(e.c.keyBasic, key), formatConst(e.c.valBasic, v))
+		sep = " "
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// examples works out the input and output of the generated examples.
+func (c *config) examples() (*exampleData, error) {
+	if c.keyBasic == nil || c.valBasic == nil {
+		return nil, errors.New("the key and value must have predeclared underlying types")
+	}
+	keys := literals(c.keyBasic, true)
+	vals := literals(c.valBasic, false)
+	if keys == nil || vals == nil {
+		return nil, fmt.Errorf("no example constants of %s or %s", c.Key, c.Value)
+	}
+	e := &evaluator{c: c, fset: token.NewFileSet(), keys: keys}
+
+	consts := make([]constant.Value, len(vals))
+	for i, lit := range vals {
+		v, err := e.eval(c.valBasic.Name(), lit, nil)
+		if err != nil {
+			return nil, err
+		}
+		consts[i] = v
+	}
+	val := func(i int) int {
+		return i % len(vals)
+	}
+
+	// each input has a value that may fail the contains check
+	// and they share two keys so their values get merged
+	build := func(items [][2]int) (string, model) {
+		var elems []string
+		m := model{}
+		for _, it := range items {
+			k, v := it[0], val(it[1])
+			elems = append(elems, keys[k]+": "+vals[v])
+			m[k] = consts[v]
+		}
+		return strings.Join(elems, ", "), m
+	}
+	d := &exampleData{}
+	var x, y model
+	d.X, x = build([][2]int{{0, 1}, {1, 0}, {2, 2}})
+	d.Y, y = build([][2]int{{1, 1}, {2, 3}, {3, 1}})
+
+	// Add the same key three times
+	d.AddKey = keys[0]
+	added := model{}
+	for i := range 3 {
+		v := consts[val(i)]
+		_, seen, err := e.get(added, 0)
+		if err != nil {
+			return nil, err
+		}
+		ok, err := e.contains(v)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			added[0] = v
+		}
+		d.AddValues = append(d.AddValues, vals[val(i)])
+		d.Add = append(d.Add, fmt.Sprint(!seen && ok, " ", e.print(added)))
+	}
+
+	for _, op := range []struct {
+		out *string
+		f   func(x, y model) (model, error)
+	}{
+		{&d.Union, e.union},
+		{&d.Intersect, e.intersect},
+		{&d.Diff, func(x, y model) (model, error) { return e.diff(x, y, false) }},
+		{&d.SymDiff, func(x, y model) (model, error) { return e.diff(x, y, true) }},
+		{&d.Purge, func(x, _ model) (model, error) { return e.diff(x, nil, false) }},
+	} {
+		m, err := op.f(x, y)
+		if err != nil {
+			return nil, err
+		}
+		*op.out = e.print(m)
+	}
+
+	n := 0
+	for k := range x {
+		if _, ok, err := e.get(x, k); err != nil {
+			return nil, err
+		} else if ok {
+			n++
+		}
+	}
+	d.Len = fmt.Sprint(len(x), " ", n)
+	return d, nil
+}
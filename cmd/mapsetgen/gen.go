@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// config is everything needed to generate the methods of one type.
+type config struct {
+	Package  string
+	Type     string
+	Key      string
+	Value    string
+	Contains string
+	Merge    string
+
+	// ImportFlag is the comma-separated list of import paths used by Contains and Merge.
+	ImportFlag string
+	// Imports are the import specs of the generated file,
+	// starting with those used by the declarations of Key and Value.
+	Imports []string
+
+	// keyBasic and valBasic are the underlying types of Key and Value
+	// if they are predeclared, for generating examples.
+	keyBasic, valBasic *types.Basic
+}
+
+// findType looks for the declaration of a named map type in the non-test Go files of dir
+// and returns a config with the package, key, and value filled in.
+func findType(dir, name string) (*config, error) {
+	fset := token.NewFileSet()
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	decls := map[string]ast.Expr{}
+	var (
+		found *ast.TypeSpec
+		in    *ast.File
+	)
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				decls[ts.Name.Name] = ts.Type
+				if ts.Name.Name == name {
+					found, in = ts, f
+				}
+			}
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("type %s not found in %s", name, dir)
+	}
+	if found.TypeParams != nil {
+		return nil, fmt.Errorf("%s: generic types are not supported", name)
+	}
+	mt, ok := found.Type.(*ast.MapType)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a map type", name)
+	}
+	imports, err := typeImports(in, mt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return &config{
+		Package:  in.Name.Name,
+		Type:     name,
+		Key:      node(fset, mt.Key),
+		Value:    node(fset, mt.Value),
+		Imports:  imports,
+		keyBasic: basic(decls, mt.Key),
+		valBasic: basic(decls, mt.Value),
+	}, nil
+}
+
+// basic returns the predeclared type that is the underlying type of e, if any.
+// Only types declared in the package are followed.
+func basic(decls map[string]ast.Expr, e ast.Expr) *types.Basic {
+	for range len(decls) + 1 {
+		id, ok := e.(*ast.Ident)
+		if !ok {
+			return nil
+		}
+		if d, ok := decls[id.Name]; ok {
+			e = d
+			continue
+		}
+		tn, ok := types.Universe.Lookup(id.Name).(*types.TypeName)
+		if !ok {
+			return nil
+		}
+		b, _ := tn.Type().(*types.Basic)
+		return b
+	}
+	// the declarations are cyclic
+	return nil
+}
+
+// typeImports returns the import specs of f that the key and value of mt refer to.
+func typeImports(f *ast.File, mt *ast.MapType) ([]string, error) {
+	var specs []string
+	var err error
+	ast.Inspect(mt, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok || err != nil {
+			return err == nil
+		}
+		id, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		for _, imp := range f.Imports {
+			p, _ := strconv.Unquote(imp.Path.Value)
+			switch {
+			case imp.Name != nil && imp.Name.Name == id.Name:
+				specs = append(specs, imp.Name.Name+" "+imp.Path.Value)
+			case imp.Name == nil && pkgName(p) == id.Name:
+				specs = append(specs, imp.Path.Value)
+			default:
+				continue
+			}
+			return false
+		}
+		err = fmt.Errorf("no import of package %s found; name the import %s explicitly", id.Name, id.Name)
+		return false
+	})
+	return specs, err
+}
+
+var majorVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+// pkgName guesses the name of the package with import path p
+// from its last element, skipping a major version suffix
+// and trimming anything after a dot, as in gopkg.in/yaml.v3.
+func pkgName(p string) string {
+	name := path.Base(p)
+	if majorVersion.MatchString(name) && path.Dir(p) != "." {
+		name = path.Base(path.Dir(p))
+	}
+	name, _, _ = strings.Cut(name, ".")
+	return name
+}
+
+func node(fset *token.FileSet, n ast.Node) string {
+	var b strings.Builder
+	printer.Fprint(&b, fset, n)
+	return b.String()
+}
+
+// generate returns the formatted source of the methods.
+func (c *config) generate() ([]byte, error) {
+	if c.Contains == "" {
+		c.Contains = "true"
+	}
+	if c.Merge == "" {
+		c.Merge = "l"
+	}
+	for _, e := range [...]string{c.Contains, c.Merge} {
+		if _, err := parser.ParseExpr(e); err != nil {
+			return nil, fmt.Errorf("invalid expression %q: %w", e, err)
+		}
+	}
+	imports := append([]string{strconv.Quote("iter")}, c.Imports...)
+	if c.ImportFlag != "" {
+		for _, p := range strings.Split(c.ImportFlag, ",") {
+			imports = append(imports, strconv.Quote(strings.TrimSpace(p)))
+		}
+	}
+	slices.Sort(imports)
+	c.Imports = slices.Compact(imports)
+	return execute(methods, c)
+}
+
+// generateExamples returns the formatted source of the examples of the methods.
+// It must be called after generate.
+func (c *config) generateExamples() ([]byte, error) {
+	ex, err := c.examples()
+	if err != nil {
+		return nil, err
+	}
+	return execute(examples, struct {
+		*config
+		Ex *exampleData
+	}{c, ex})
+}
+
+func execute(t *template.Template, data any) ([]byte, error) {
+	var b bytes.Buffer
+	if err := t.Execute(&b, data); err != nil {
+		return nil, err
+	}
+	out, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, errors.Join(err, fmt.Errorf("generated:\n%s", b.Bytes()))
+	}
+	return out, nil
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const counts = `package counts
+
+type Counts map[string]int
+`
+
+// more has key and value types of each kind that examples are generated for.
+const more = `package counts
+
+type (
+	Weight  float32
+	Weights map[int]Weight
+	Flags   map[float64]bool
+	Labels  map[uint8]string
+)
+`
+
+// seen and names need imports for their key and value types and for their expressions.
+const seen = `package counts
+
+import clock "time"
+
+type Seen map[string]clock.Time
+`
+
+const names = `package counts
+
+import "time"
+
+type Names map[time.Weekday]string
+`
+
+const importsTest = `package counts
+
+import (
+	"testing"
+	"time"
+)
+
+func TestImports(t *testing.T) {
+	s := Seen{"a": time.Unix(1, 0), "b": {}}
+	if got := s.Union(Seen{"a": time.Unix(2, 0)}); len(got) != 1 || got["a"].Unix() != 2 {
+		t.Fatalf("Seen.Union = %v", got)
+	}
+
+	n := Names{time.Monday: "a", time.Tuesday: " "}
+	if got := n.Union(Names{time.Monday: "b"}); len(got) != 1 || got[time.Monday] != "a,b" {
+		t.Fatalf("Names.Union = %v", got)
+	}
+}
+`
+
+const countsTest = `package counts
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/jimmyfrasche/mapset"
+)
+
+func contains(v int) bool { return v != 0 }
+
+func merge(l, r int) int { return l + r }
+
+func TestCounts(t *testing.T) {
+	sets := []Counts{
+		{},
+		{"a": 1, "b": 0, "c": 2, "d": -2},
+		{"c": 3, "d": 2, "e": 4},
+		{"a": -1, "e": 0},
+	}
+	for _, x := range sets {
+		for _, y := range sets {
+			check := func(name string, got, want any) {
+				t.Helper()
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("%v.%s(%v): got %v, want %v", x, name, y, got, want)
+				}
+			}
+			inPlace := func(f func(Counts, Counts)) Counts {
+				z := maps.Clone(x)
+				f(z, y)
+				return z
+			}
+			check("Union", x.Union(y), mapset.Union(x, y, contains, merge))
+			check("Intersect", x.Intersect(y), mapset.Intersect(x, y, contains, merge))
+			check("Diff", x.Diff(y), mapset.Diff(x, y, contains))
+			check("SymDiff", x.SymDiff(y), mapset.SymDiff(x, y, contains))
+			check("UnionWith", inPlace(Counts.UnionWith), mapset.Union(x, y, contains, merge))
+			check("IntersectWith", inPlace(Counts.IntersectWith), mapset.Intersect(x, y, contains, merge))
+			check("DiffWith", inPlace(Counts.DiffWith), mapset.Diff(x, y, contains))
+			check("SymDiffWith", inPlace(Counts.SymDiffWith), mapset.SymDiff(x, y, contains))
+			check("UnionAll", x.UnionAll(y, x), mapset.UnionAll(contains, merge, x, y, x))
+			check("IntersectAll", x.IntersectAll(y, x), mapset.IntersectAll(contains, merge, x, y, x))
+			check("DiffAll", x.DiffAll(y), mapset.DiffAll(x, contains, y))
+			check("Equal", x.Equal(y), mapset.Equal(x, y, contains))
+			check("Subset", x.Subset(y), mapset.Subset(x, y, contains))
+			check("ProperSubset", x.ProperSubset(y), mapset.ProperSubset(x, y, contains))
+			check("Superset", x.Superset(y), mapset.Subset(y, x, contains))
+			check("ProperSuperset", x.ProperSuperset(y), mapset.ProperSubset(y, x, contains))
+			check("Disjoint", x.Disjoint(y), mapset.Disjoint(x, y, contains))
+		}
+		if x.Len() != mapset.Len(x, contains) {
+			t.Errorf("%v.Len() = %d", x, x.Len())
+		}
+		if got := x.Clone(); !maps.Equal(got, mapset.Clone(x, contains)) {
+			t.Errorf("%v.Clone() = %v", x, got)
+		}
+		if got := maps.Collect(x.All()); !maps.Equal(got, mapset.Clone(x, contains)) {
+			t.Errorf("%v.All() = %v", x, got)
+		}
+		if got, want := slices.Sorted(slices.Values(x.Keys())), slices.Sorted(maps.Keys(mapset.Clone(x, contains))); !slices.Equal(got, want) {
+			t.Errorf("%v.Keys() = %v", x, got)
+		}
+	}
+
+	x := Counts{"a": 1, "b": 0}
+	if x.Add("c", 0) || x.Contains("c") {
+		t.Fatal("Add of a value not in the set must do nothing")
+	}
+	if !x.Add("b", 2) || x.Add("b", 3) || x["b"] != 3 {
+		t.Fatalf("Add = %v", x)
+	}
+	x.Extend(0, "f", "g")
+	x.Extend(7, "a", "h")
+	if len(x) != 3 || x["a"] != 7 || x["h"] != 7 || x.Contains("f") {
+		t.Fatalf("Extend = %v", x)
+	}
+	x.ExtendSeq(1, slices.Values([]string{"i"}))
+	if f := x.Filter(func(k string) bool { return k != "a" }); len(f) != 3 || f.Contains("a") {
+		t.Fatalf("Filter = %v", f)
+	}
+	for range 4 {
+		if k, ok := x.Pop(); !ok || x.Contains(k) {
+			t.Fatal("Pop must remove the key it returns")
+		}
+	}
+	if _, ok := x.Any(); ok {
+		t.Fatal("Any of an empty set must fail")
+	}
+	x["z"] = 0
+	x.Clear()
+	if len(x) != 0 {
+		t.Fatal("Clear must remove all keys")
+	}
+}
+`
+
+func TestGenerate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a module")
+	}
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+
+	dir := t.TempDir()
+	write := func(name, src string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	write("go.mod", "module counts\n\ngo 1.23\n\nrequire github.com/jimmyfrasche/mapset v0.0.0\n\nreplace github.com/jimmyfrasche/mapset => "+root+"\n")
+	write("counts.go", counts)
+	write("counts_test.go", countsTest)
+	write("seen.go", seen)
+	write("names.go", names)
+	write("imports_test.go", importsTest)
+	write("more.go", more)
+
+	for _, g := range []struct {
+		typ, contains, merge, imports string
+		examples                      bool
+	}{
+		{"Counts", "v != 0", "l + r", "", true},
+		{"Weights", "v < 2", "l * r / 3", "", true},
+		{"Flags", "v", "l != r", "", true},
+		{"Labels", `v != ""`, "max(l, r) + min(l, r)", "", true},
+		{"Seen", "!v.IsZero()", "clock.Unix(max(l.Unix(), r.Unix()), 0)", "", false},
+		{"Names", `strings.TrimSpace(v) != ""`, `strings.Join([]string{l, r}, ",")`, "strings", false},
+	} {
+		c, err := findType(dir, g.typ)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.Contains = g.contains
+		c.Merge = g.merge
+		c.ImportFlag = g.imports
+		src, err := c.generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		write(strings.ToLower(g.typ)+"_mapset.go", string(src))
+
+		test, err := c.generateExamples()
+		if (err == nil) != g.examples {
+			t.Fatalf("%s: generating examples: %v", g.typ, err)
+		}
+		if err == nil {
+			write(strings.ToLower(g.typ)+"_mapset_test.go", string(test))
+		}
+	}
+
+	cmd := exec.Command(gobin, "test", "-v", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("go test: %v\n%s", err, out)
+	}
+	// make sure the examples were run
+	if !strings.Contains(string(out), "--- PASS: ExampleCounts_Union") {
+		t.Fatalf("examples not run:\n%s", out)
+	}
+}
+
+func TestFindTypeErrors(t *testing.T) {
+	dir := t.TempDir()
+	src := "package p\n\ntype S []int\n\ntype G[K comparable] map[K]int\n\ntype U map[string]missing.T\n"
+	if err := os.WriteFile(filepath.Join(dir, "p.go"), []byte(src), 0o666); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"S", "G", "U", "Missing"} {
+		if _, err := findType(dir, name); err == nil {
+			t.Errorf("findType(%q) should fail", name)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Command mapsetgen generates the set methods of package mapset for a named map type,
+// with a fixed contains and merge expression compiled in instead of a ContainsFunc and MergeFunc.
+//
+// It is meant to be used with go generate:
+//
+//	type Counts map[string]int
+//
+//	//go:generate mapsetgen -type Counts -contains "v != 0" -merge "l + r"
+//
+// The contains expression tests the value v and the merge expression combines the values l and r.
+// They default to true and l, the behavior of a nil ContainsFunc and MergeFunc.
+// The expressions are wrapped in small unexported methods that the compiler inlines.
+// Packages the expressions use are imported with -imports, a comma-separated list of import paths.
+// The imports the key and value types need are copied from the file that declares the type.
+//
+// This writes the methods to counts_mapset.go and examples of them to counts_mapset_test.go.
+//
+// The output of the examples is worked out by evaluating the expressions as constant expressions
+// for a few values chosen by mapsetgen,
+// so examples are only generated when the key and value have predeclared underlying types
+// and the expressions only use operators, builtins, v, l, and r.
+// Otherwise only the methods are written and any examples from an earlier run are removed.
+package main
+
+import (
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("mapsetgen: ")
+
+	typ := flag.String("type", "", "name of the map type (required)")
+	contains := flag.String("contains", "", "expression of v that decides if v is in the set")
+	merge := flag.String("merge", "", "expression of l and r that merges two values")
+	imports := flag.String("imports", "", "comma-separated import paths of the packages used by the expressions")
+	dir := flag.String("dir", ".", "directory of the package containing the type")
+	flag.Parse()
+
+	if *typ == "" || flag.NArg() != 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	c, err := findType(*dir, *typ)
+	if err != nil {
+		log.Fatal(err)
+	}
+	c.Contains = *contains
+	c.Merge = *merge
+	c.ImportFlag = *imports
+
+	src, err := c.generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	base := filepath.Join(*dir, strings.ToLower(*typ)+"_mapset")
+	if err := os.WriteFile(base+".go", src, 0o666); err != nil {
+		log.Fatal(err)
+	}
+
+	test, err := c.generateExamples()
+	if err != nil {
+		log.Printf("not generating examples: %v", err)
+		// examples from an earlier run were computed from other expressions
+		if err := os.Remove(base + "_test.go"); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := os.WriteFile(base+"_test.go", test, 0o666); err != nil {
+		log.Fatal(err)
+	}
+}
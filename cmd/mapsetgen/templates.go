@@ -0,0 +1,451 @@
+package main
+
+import "text/template"
+
+const header = `// Code generated by mapsetgen -type {{.Type}} -contains {{printf "%q" .Contains}} -merge {{printf "%q" .Merge}}{{with .ImportFlag}} -imports {{printf "%q" .}}{{end}}; DO NOT EDIT.
+
+`
+
+var methods = template.Must(template.New("methods").Parse(header + `package {{.Package}}
+
+import (
+{{- range .Imports}}
+	{{.}}
+{{- end}}
+)
+
+// contains is the ContainsFunc of {{.Type}}.
+func ({{.Type}}) contains(v {{.Value}}) bool {
+	return {{.Contains}}
+}
+
+// merge is the MergeFunc of {{.Type}}.
+func ({{.Type}}) merge(l, r {{.Value}}) {{.Value}} {
+	return {{.Merge}}
+}
+
+// Add sets m[key] to v if v is in the set and reports whether key is new.
+// If v is not in the set, m is unchanged and Add returns false.
+func (m {{.Type}}) Add(key {{.Key}}, v {{.Value}}) bool {
+	seen := m.Contains(key)
+	if !m.contains(v) {
+		return false
+	}
+	m[key] = v
+	return !seen
+}
+
+// Extend sets m[key] to v for each key, if v is in the set.
+func (m {{.Type}}) Extend(v {{.Value}}, keys ...{{.Key}}) {
+	if !m.contains(v) {
+		return
+	}
+	for _, k := range keys {
+		m[k] = v
+	}
+}
+
+// ExtendSeq sets m[key] to v for each key of seq, if v is in the set.
+func (m {{.Type}}) ExtendSeq(v {{.Value}}, seq iter.Seq[{{.Key}}]) {
+	if !m.contains(v) {
+		return
+	}
+	for k := range seq {
+		m[k] = v
+	}
+}
+
+// Delete removes key and reports if it was present.
+func (m {{.Type}}) Delete(key {{.Key}}) bool {
+	seen := m.Contains(key)
+	delete(m, key)
+	return seen
+}
+
+// Remove keys from m.
+func (m {{.Type}}) Remove(keys ...{{.Key}}) {
+	for _, k := range keys {
+		delete(m, k)
+	}
+}
+
+func (m {{.Type}}) Union(o {{.Type}}) {{.Type}} {
+	out := make({{.Type}}, max(len(m), len(o)))
+	for k, v := range m {
+		if m.contains(v) {
+			out[k] = v
+		}
+	}
+	for k, v := range o {
+		if !m.contains(v) {
+			continue
+		}
+		if l, ok := out[k]; !ok {
+			// new key, add
+			out[k] = v
+		} else if v := m.merge(l, v); m.contains(v) {
+			// key exists in both maps, do a merge
+			out[k] = v
+		} else {
+			// two valid entries merged into an invalid entry
+			delete(out, k)
+		}
+	}
+	return out
+}
+
+func (m {{.Type}}) Intersect(o {{.Type}}) {{.Type}} {
+	out := make({{.Type}}, min(len(m), len(o)))
+	for k, v := range m {
+		vp, ok := o[k]
+		if ok && m.contains(v) && m.contains(vp) {
+			if vf := m.merge(v, vp); m.contains(vf) {
+				out[k] = vf
+			}
+		}
+	}
+	return out
+}
+
+func (m {{.Type}}) Diff(o {{.Type}}) {{.Type}} {
+	out := make({{.Type}}, len(m))
+	for k, v := range m {
+		if m.contains(v) && !o.Contains(k) {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func (m {{.Type}}) SymDiff(o {{.Type}}) {{.Type}} {
+	out := {{.Type}}{}
+	for k, v := range m {
+		// k in m but not o
+		if m.contains(v) && !o.Contains(k) {
+			out[k] = v
+		}
+	}
+	for k, v := range o {
+		// k in o but not m
+		if m.contains(v) && !m.Contains(k) {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// UnionWith adds the items of o to m.
+func (m {{.Type}}) UnionWith(o {{.Type}}) {
+	m.Purge()
+	m.unionWith(o)
+}
+
+// unionWith is UnionWith without the initial Purge of m.
+func (m {{.Type}}) unionWith(o {{.Type}}) {
+	for k, v := range o {
+		if !m.contains(v) {
+			continue
+		}
+		if l, ok := m[k]; !ok {
+			// new key, add
+			m[k] = v
+		} else if v := m.merge(l, v); m.contains(v) {
+			// key exists in both maps, do a merge
+			m[k] = v
+		} else {
+			// two valid entries merged into an invalid entry
+			delete(m, k)
+		}
+	}
+}
+
+// IntersectWith removes the items of m that are not in o.
+func (m {{.Type}}) IntersectWith(o {{.Type}}) {
+	for k, v := range m {
+		vp, ok := o[k]
+		if ok && m.contains(v) && m.contains(vp) {
+			if vf := m.merge(v, vp); m.contains(vf) {
+				m[k] = vf
+				continue
+			}
+		}
+		delete(m, k)
+	}
+}
+
+// DiffWith removes the items of o from m.
+func (m {{.Type}}) DiffWith(o {{.Type}}) {
+	for k, v := range m {
+		if !m.contains(v) || o.Contains(k) {
+			delete(m, k)
+		}
+	}
+}
+
+// SymDiffWith sets m to the symmetric difference of m and o.
+func (m {{.Type}}) SymDiffWith(o {{.Type}}) {
+	for k, v := range o {
+		if !m.contains(v) {
+			continue
+		}
+		if m.Contains(k) {
+			// k in both
+			delete(m, k)
+		} else {
+			// k in o but not m
+			m[k] = v
+		}
+	}
+	m.Purge()
+}
+
+// UnionAll returns the union of m and all of os.
+func (m {{.Type}}) UnionAll(os ...{{.Type}}) {{.Type}} {
+	out := m.Clone()
+	for _, o := range os {
+		out.unionWith(o)
+	}
+	return out
+}
+
+// IntersectAll returns the intersection of m and all of os.
+func (m {{.Type}}) IntersectAll(os ...{{.Type}}) {{.Type}} {
+	out := {{.Type}}{}
+items:
+	for k, acc := range m {
+		if !m.contains(acc) {
+			continue
+		}
+		for _, o := range os {
+			v, ok := o[k]
+			if !ok || !m.contains(v) {
+				continue items
+			}
+			if acc = m.merge(acc, v); !m.contains(acc) {
+				continue items
+			}
+		}
+		out[k] = acc
+	}
+	return out
+}
+
+// DiffAll returns the items of m that are in none of os.
+func (m {{.Type}}) DiffAll(os ...{{.Type}}) {{.Type}} {
+	out := {{.Type}}{}
+items:
+	for k, v := range m {
+		if !m.contains(v) {
+			continue
+		}
+		for _, o := range os {
+			if o.Contains(k) {
+				continue items
+			}
+		}
+		out[k] = v
+	}
+	return out
+}
+
+func (m {{.Type}}) Contains(k {{.Key}}) bool {
+	v, ok := m[k]
+	return ok && m.contains(v)
+}
+
+func (m {{.Type}}) subset(o {{.Type}}) (int, bool) {
+	n := 0
+	for k, v := range m {
+		if !m.contains(v) {
+			continue
+		}
+		if !o.Contains(k) {
+			return 0, false
+		}
+		n++
+	}
+	return n, true
+}
+
+func (m {{.Type}}) Equal(o {{.Type}}) bool {
+	n, ok := m.subset(o)
+	return ok && n == o.Len()
+}
+
+func (m {{.Type}}) Subset(o {{.Type}}) bool {
+	_, ok := m.subset(o)
+	return ok
+}
+
+func (m {{.Type}}) ProperSubset(o {{.Type}}) bool {
+	n, ok := m.subset(o)
+	return ok && n < o.Len()
+}
+
+func (m {{.Type}}) Disjoint(o {{.Type}}) bool {
+	for k, v := range m {
+		if m.contains(v) && o.Contains(k) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m {{.Type}}) Len() int {
+	var n int
+	for _, v := range m {
+		if m.contains(v) {
+			n++
+		}
+	}
+	return n
+}
+
+func (m {{.Type}}) Clone() {{.Type}} {
+	out := make({{.Type}}, len(m))
+	for k, v := range m {
+		if m.contains(v) {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func (m {{.Type}}) Keys() []{{.Key}} {
+	var out []{{.Key}}
+	for k, v := range m {
+		if m.contains(v) {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+func (m {{.Type}}) Values() []{{.Value}} {
+	var out []{{.Value}}
+	for _, v := range m {
+		if m.contains(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func (m {{.Type}}) Purge() {
+	for k, v := range m {
+		if !m.contains(v) {
+			delete(m, k)
+		}
+	}
+}
+
+// All returns an iterator over the items of m.
+func (m {{.Type}}) All() iter.Seq2[{{.Key}}, {{.Value}}] {
+	return func(yield func({{.Key}}, {{.Value}}) bool) {
+		for k, v := range m {
+			if m.contains(v) && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+func (m {{.Type}}) Superset(o {{.Type}}) bool {
+	return o.Subset(m)
+}
+
+func (m {{.Type}}) ProperSuperset(o {{.Type}}) bool {
+	return o.ProperSubset(m)
+}
+
+// Any returns an arbitrary key of m or false if m is empty.
+func (m {{.Type}}) Any() ({{.Key}}, bool) {
+	for k := range m.All() {
+		return k, true
+	}
+	var zero {{.Key}}
+	return zero, false
+}
+
+// Pop removes and returns an arbitrary key of m or false if m is empty.
+func (m {{.Type}}) Pop() ({{.Key}}, bool) {
+	k, ok := m.Any()
+	if ok {
+		delete(m, k)
+	}
+	return k, ok
+}
+
+// Filter returns a new {{.Type}} of the items of m whose keys keep returns true for.
+func (m {{.Type}}) Filter(keep func({{.Key}}) bool) {{.Type}} {
+	out := {{.Type}}{}
+	for k, v := range m.All() {
+		if keep(k) {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// Clear removes all items from m.
+func (m {{.Type}}) Clear() {
+	clear(m)
+}
+`))
+
+var examples = template.Must(template.New("examples").Parse(header + `package {{.Package}}
+
+import "fmt"
+
+func Example{{.Type}}_Add() {
+	m := {{.Type}}{}
+{{- range .Ex.AddValues}}
+	fmt.Println(m.Add({{$.Ex.AddKey}}, {{.}}), m)
+{{- end}}
+
+	// Output:
+{{- range .Ex.Add}}
+	// {{.}}
+{{- end}}
+}
+
+func Example{{.Type}}_Union() {
+	x := {{.Type}}{ {{- .Ex.X -}} }
+	y := {{.Type}}{ {{- .Ex.Y -}} }
+	fmt.Println(x.Union(y))
+
+	// Output:
+	// {{.Ex.Union}}
+}
+
+func Example{{.Type}}_Intersect() {
+	x := {{.Type}}{ {{- .Ex.X -}} }
+	y := {{.Type}}{ {{- .Ex.Y -}} }
+	fmt.Println(x.Intersect(y))
+
+	// Output:
+	// {{.Ex.Intersect}}
+}
+
+func Example{{.Type}}_Diff() {
+	x := {{.Type}}{ {{- .Ex.X -}} }
+	y := {{.Type}}{ {{- .Ex.Y -}} }
+	fmt.Println(x.Diff(y))
+	fmt.Println(x.SymDiff(y))
+
+	// Output:
+	// {{.Ex.Diff}}
+	// {{.Ex.SymDiff}}
+}
+
+func Example{{.Type}}_Purge() {
+	x := {{.Type}}{ {{- .Ex.X -}} }
+	fmt.Println(len(x), x.Len())
+	x.Purge()
+	fmt.Println(x)
+
+	// Output:
+	// {{.Ex.Len}}
+	// {{.Ex.Purge}}
+}
+`))
@@ -0,0 +1,93 @@
+package mapset
+
+import "fmt"
+
+// EqualFunc tests equality of the items whose values pass the [ContainsFunc] check
+// using eq to compare the values of each key.
+//
+// A nil eq compares only the keys, as in [Equal].
+func EqualFunc[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFunc[V], eq func(V, V) bool) bool {
+	if eq == nil {
+		return Equal(lhs, rhs, contains)
+	}
+	n := 0
+	for k, v := range lhs {
+		if !contains.Check(v) {
+			continue
+		}
+		vp, ok := rhs[k]
+		if !ok || !contains.Check(vp) || !eq(v, vp) {
+			return false
+		}
+		n++
+	}
+	return n == Len(rhs, contains)
+}
+
+// EqualValues is [EqualFunc] with == as the comparison.
+func EqualValues[K, V comparable, M ~map[K]V](lhs, rhs M, contains ContainsFunc[V]) bool {
+	return EqualFunc(lhs, rhs, contains, func(a, b V) bool {
+		return a == b
+	})
+}
+
+// Relation is the result of [Compare].
+type Relation int
+
+const (
+	// RelEqual means both maps have the same keys.
+	RelEqual Relation = iota
+	// RelProperSubset means every key of lhs is in rhs but rhs has more keys.
+	RelProperSubset
+	// RelProperSuperset means every key of rhs is in lhs but lhs has more keys.
+	RelProperSuperset
+	// RelDisjoint means no key is in both maps and both maps have keys.
+	RelDisjoint
+	// RelOverlapping means some but not all keys are in both maps.
+	RelOverlapping
+)
+
+func (r Relation) String() string {
+	switch r {
+	case RelEqual:
+		return "equal"
+	case RelProperSubset:
+		return "proper subset"
+	case RelProperSuperset:
+		return "proper superset"
+	case RelDisjoint:
+		return "disjoint"
+	case RelOverlapping:
+		return "overlapping"
+	}
+	return fmt.Sprintf("Relation(%d)", int(r))
+}
+
+// Compare reports how the keys of lhs and rhs whose values pass the [ContainsFunc] check relate.
+// Each map is only iterated once.
+//
+// An empty map is equal to another empty map and a proper subset of any other map.
+func Compare[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFunc[V]) Relation {
+	var nl, common int
+	for k, v := range lhs {
+		if !contains.Check(v) {
+			continue
+		}
+		nl++
+		if Contains(rhs, k, contains) {
+			common++
+		}
+	}
+	nr := Len(rhs, contains)
+	switch {
+	case common == nl && common == nr:
+		return RelEqual
+	case common == nl:
+		return RelProperSubset
+	case common == nr:
+		return RelProperSuperset
+	case common == 0:
+		return RelDisjoint
+	}
+	return RelOverlapping
+}
@@ -0,0 +1,98 @@
+package mapset_test
+
+import (
+	"testing"
+
+	"github.com/jimmyfrasche/mapset"
+	"github.com/jimmyfrasche/mapset/internal/conformance"
+)
+
+// subsetLike is the method set shared by Set and Bool but not multiset.Of,
+// whose inclusion takes multiplicity into account.
+type subsetLike[S any, V any] interface {
+	~map[string]V
+	Subset(S) bool
+	ProperSubset(S) bool
+	Superset(S) bool
+	ProperSuperset(S) bool
+}
+
+// checkSubsets checks the subset methods of every pair of sets against Subset and ProperSubset.
+func checkSubsets[S subsetLike[S, V], V any](t *testing.T, sets []S, contains mapset.ContainsFunc[V]) {
+	t.Helper()
+	for _, x := range sets {
+		for _, y := range sets {
+			check := func(name string, got, want bool) {
+				t.Helper()
+				if got != want {
+					t.Errorf("%v.%s(%v): got %v, want %v", x, name, y, got, want)
+				}
+			}
+			check("Subset", x.Subset(y), mapset.Subset(x, y, contains))
+			check("ProperSubset", x.ProperSubset(y), mapset.ProperSubset(x, y, contains))
+			check("Superset", x.Superset(y), mapset.Subset(y, x, contains))
+			check("ProperSuperset", x.ProperSuperset(y), mapset.ProperSubset(y, x, contains))
+		}
+	}
+}
+
+func TestSetConformance(t *testing.T) {
+	sets := []mapset.Set[string]{
+		{},
+		{"a": {}},
+		{"a": {}, "b": {}},
+		{"b": {}, "c": {}},
+		{"a": {}, "b": {}, "c": {}},
+	}
+	conformance.Check(t, sets, nil, nil, nil)
+	checkSubsets(t, sets, nil)
+}
+
+func TestBoolConformance(t *testing.T) {
+	bools := []mapset.Bool[string]{
+		{},
+		{"a": true},
+		{"a": false},
+		{"a": true, "b": true},
+		{"a": true, "b": false},
+		{"b": true, "c": true},
+		{"a": true, "b": true, "c": true},
+	}
+	conformance.Check(t, bools, mapset.NonZero[bool], mapset.Either, mapset.Either)
+	checkSubsets(t, bools, mapset.NonZero[bool])
+}
+
+func TestPopAnyFilterClear(t *testing.T) {
+	s := mapset.Set[string]{"a": {}, "b": {}}
+	b := mapset.Bool[string]{"a": true, "b": true, "c": false}
+
+	if f := s.Filter(func(k string) bool { return k != "a" }); !f.Equal(mapset.Set[string]{"b": {}}) {
+		t.Fatalf("Set.Filter = %v", f)
+	}
+	if f := b.Filter(func(k string) bool { return k != "a" }); !f.Equal(mapset.Bool[string]{"b": true}) {
+		t.Fatalf("Bool.Filter = %v", f)
+	}
+
+	for range 2 {
+		if k, ok := s.Any(); !ok || !s.Contains(k) {
+			t.Fatal("Set.Any must return a key of s")
+		}
+		if k, ok := s.Pop(); !ok || s.Contains(k) {
+			t.Fatal("Set.Pop must remove the key it returns")
+		}
+		if k, ok := b.Pop(); !ok || k == "c" || b.Contains(k) {
+			t.Fatal("Bool.Pop must remove a true key")
+		}
+	}
+	if _, ok := s.Pop(); ok {
+		t.Fatal("Set.Pop of empty set must fail")
+	}
+	if _, ok := b.Any(); ok {
+		t.Fatal("Bool.Any must skip false keys")
+	}
+
+	b.Clear()
+	if len(b) != 0 {
+		t.Fatal("Clear must remove all keys")
+	}
+}
@@ -0,0 +1,165 @@
+package mapset
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SlicePolicy decides how [DeepMerge] combines two []any.
+type SlicePolicy int
+
+const (
+	// SliceReplace treats slices like any other value, so the [ScalarPolicy] applies.
+	SliceReplace SlicePolicy = iota
+	// SliceAppend appends the rhs slice to the lhs slice.
+	SliceAppend
+	// SliceUnion appends the elements of the rhs slice that are not in the lhs slice,
+	// using [reflect.DeepEqual] to compare elements.
+	SliceUnion
+)
+
+// ScalarPolicy decides how [DeepMerge] combines two values that differ
+// and are not both maps or, unless the [SlicePolicy] is SliceReplace, both slices.
+type ScalarPolicy int
+
+const (
+	// ScalarRight keeps the rhs value.
+	ScalarRight ScalarPolicy = iota
+	// ScalarLeft keeps the lhs value.
+	ScalarLeft
+	// ScalarError stops the merge with a [*DeepMergeError].
+	ScalarError
+)
+
+// ErrDeepMergeConflict is wrapped by the [*DeepMergeError] returned when ScalarError is used.
+var ErrDeepMergeConflict = errors.New("conflicting values")
+
+// DeepMergeError records where in the tree two values conflicted.
+type DeepMergeError struct {
+	// Path is the sequence of keys leading to the conflict.
+	Path     []string
+	Lhs, Rhs any
+}
+
+func (e *DeepMergeError) Error() string {
+	return fmt.Sprintf("%s: %v: %v ≠ %v", strings.Join(e.Path, "."), ErrDeepMergeConflict, e.Lhs, e.Rhs)
+}
+
+func (e *DeepMergeError) Unwrap() error {
+	return ErrDeepMergeConflict
+}
+
+// DeepMergeOptions configure [DeepMerge].
+// The zero value replaces slices, lets rhs win conflicts, and keeps every value.
+type DeepMergeOptions struct {
+	Slices  SlicePolicy
+	Scalars ScalarPolicy
+	// Contains decides which values are kept.
+	// A value that fails the check is purged from every map in the result, at any depth.
+	// A value in rhs that fails the check also deletes its key from lhs, so it acts as a delete marker.
+	// For example, [NonNil] lets a nil in rhs delete a key.
+	Contains ContainsFunc[any]
+}
+
+// DeepMerge creates a new configuration tree by merging rhs into lhs.
+// Values that are both map[string]any are merged recursively, as [Union] would with a recursive [MergeFunc].
+// How other values are combined is decided by opts.
+//
+// The result shares no maps or slices with lhs or rhs.
+// Layered configuration can be merged by folding DeepMerge over the layers from lowest to highest priority.
+func DeepMerge(lhs, rhs map[string]any, opts DeepMergeOptions) (map[string]any, error) {
+	out := opts.clone(lhs).(map[string]any)
+	if err := opts.mergeInto(nil, out, rhs); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// mergeInto merges rhs into out at path in place.
+// out has already been cloned.
+func (o *DeepMergeOptions) mergeInto(path []string, out, rhs map[string]any) error {
+	for k, r := range rhs {
+		if !o.Contains.Check(r) {
+			// delete marker
+			delete(out, k)
+			continue
+		}
+		l, ok := out[k]
+		if !ok {
+			out[k] = o.clone(r)
+			continue
+		}
+		v, err := o.value(append(path[:len(path):len(path)], k), l, r)
+		if err != nil {
+			return err
+		}
+		out[k] = v
+	}
+	return nil
+}
+
+// value merges two values at path that both passed the Contains check.
+// l has already been cloned.
+func (o *DeepMergeOptions) value(path []string, l, r any) (any, error) {
+	if lm, ok := l.(map[string]any); ok {
+		if rm, ok := r.(map[string]any); ok {
+			if err := o.mergeInto(path, lm, rm); err != nil {
+				return nil, err
+			}
+			return lm, nil
+		}
+	}
+	if ls, ok := l.([]any); ok && o.Slices != SliceReplace {
+		if rs, ok := r.([]any); ok {
+			for _, e := range o.clone(rs).([]any) {
+				if o.Slices == SliceUnion && containsDeep(ls, e) {
+					continue
+				}
+				ls = append(ls, e)
+			}
+			return ls, nil
+		}
+	}
+	if reflect.DeepEqual(l, r) {
+		return l, nil
+	}
+	switch o.Scalars {
+	case ScalarLeft:
+		return l, nil
+	case ScalarError:
+		return nil, &DeepMergeError{Path: path, Lhs: l, Rhs: r}
+	}
+	return o.clone(r), nil
+}
+
+func containsDeep(s []any, e any) bool {
+	for _, x := range s {
+		if reflect.DeepEqual(x, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// clone deep copies the maps and slices in v, purging values that fail the Contains check.
+func (o *DeepMergeOptions) clone(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, x := range v {
+			if o.Contains.Check(x) {
+				out[k] = o.clone(x)
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, 0, len(v))
+		for _, x := range v {
+			out = append(out, o.clone(x))
+		}
+		return out
+	}
+	return v
+}
@@ -0,0 +1,140 @@
+package mapset
+
+// Change is the old and new value of a key in a [Delta].
+type Change[V any] struct {
+	Old, New V
+}
+
+// Delta records how one map differs from another.
+//
+// A key is in at most one of Added, Removed, and Changed.
+type Delta[K comparable, V any] struct {
+	Added   map[K]V
+	Removed map[K]V
+	Changed map[K]Change[V]
+}
+
+// Empty reports whether d records no differences.
+func (d Delta[K, V]) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffReport computes the [Delta] from before to after in a single pass over each map.
+//
+// Only items whose values pass the [ContainsFunc] check are considered.
+// Added is Diff(after, before, contains) and Removed is Diff(before, after, contains).
+// Changed has the keys in both maps whose values are not equal according to eq.
+// A nil eq treats all values as unequal, so Changed has every key in both maps.
+func DiffReport[K comparable, V any, M ~map[K]V](before, after M, contains ContainsFunc[V], eq func(V, V) bool) Delta[K, V] {
+	d := Delta[K, V]{
+		Added:   map[K]V{},
+		Removed: map[K]V{},
+		Changed: map[K]Change[V]{},
+	}
+	for k, v := range before {
+		if !contains.Check(v) {
+			continue
+		}
+		vp, ok := after[k]
+		switch {
+		case !ok || !contains.Check(vp):
+			d.Removed[k] = v
+		case eq == nil || !eq(v, vp):
+			d.Changed[k] = Change[V]{v, vp}
+		}
+	}
+	for k, v := range after {
+		if contains.Check(v) && !Contains(before, k, contains) {
+			d.Added[k] = v
+		}
+	}
+	return d
+}
+
+// Apply updates m with d: adding the keys in Added,
+// removing the keys in Removed, and setting the keys in Changed to their new value.
+//
+// The old values in d are not checked against m.
+func Apply[K comparable, V any, M ~map[K]V](m M, d Delta[K, V]) {
+	for k := range d.Removed {
+		delete(m, k)
+	}
+	for k, v := range d.Added {
+		m[k] = v
+	}
+	for k, c := range d.Changed {
+		m[k] = c.New
+	}
+}
+
+// Invert returns the Delta that undoes d.
+func Invert[K comparable, V any](d Delta[K, V]) Delta[K, V] {
+	changed := make(map[K]Change[V], len(d.Changed))
+	for k, c := range d.Changed {
+		changed[k] = Change[V]{c.New, c.Old}
+	}
+	return Delta[K, V]{
+		Added:   Clone(d.Removed, nil),
+		Removed: Clone(d.Added, nil),
+		Changed: changed,
+	}
+}
+
+// Compose returns a single Delta with the same effect as applying d1 then d2.
+//
+// d2 is assumed to have been computed against the result of applying d1.
+// eq is used to drop changes that restore the original value.
+// A nil eq treats all values as unequal, so no changes are dropped.
+func Compose[K comparable, V any](d1, d2 Delta[K, V], eq func(V, V) bool) Delta[K, V] {
+	d := Delta[K, V]{
+		Added:   Clone(d1.Added, nil),
+		Removed: Clone(d1.Removed, nil),
+		Changed: Clone(d1.Changed, nil),
+	}
+	same := func(a, b V) bool {
+		return eq != nil && eq(a, b)
+	}
+	for k, v := range d2.Added {
+		if old, ok := d.Removed[k]; ok {
+			// removed then added back
+			delete(d.Removed, k)
+			if !same(old, v) {
+				d.Changed[k] = Change[V]{old, v}
+			}
+			continue
+		}
+		d.Added[k] = v
+	}
+	for k, v := range d2.Removed {
+		if _, ok := d.Added[k]; ok {
+			// added then removed
+			delete(d.Added, k)
+			continue
+		}
+		if c, ok := d.Changed[k]; ok {
+			// changed then removed
+			delete(d.Changed, k)
+			d.Removed[k] = c.Old
+			continue
+		}
+		d.Removed[k] = v
+	}
+	for k, c := range d2.Changed {
+		if _, ok := d.Added[k]; ok {
+			// added then changed
+			d.Added[k] = c.New
+			continue
+		}
+		if c1, ok := d.Changed[k]; ok {
+			// changed twice
+			if same(c1.Old, c.New) {
+				delete(d.Changed, k)
+			} else {
+				d.Changed[k] = Change[V]{c1.Old, c.New}
+			}
+			continue
+		}
+		d.Changed[k] = c
+	}
+	return d
+}
@@ -5,4 +5,5 @@
 // When multiple values need to be considered for the same key a [MergeFunc] defines what value that key takes.
 //
 // The [Set] and [Bool] types package these operations into types that can be used by conversion from a map[K]struct{} or map[K]bool, respectively.
+// The [With] type packages them for any map along with its ContainsFunc and MergeFunc.
 package mapset
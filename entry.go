@@ -0,0 +1,268 @@
+package mapset
+
+// EntryContainsFunc decides if an item belongs to a set based on its key and value.
+// A nil EntryContainsFunc always returns true.
+//
+// EntryContainsFunc generalizes [ContainsFunc] for when membership depends on the key.
+type EntryContainsFunc[K comparable, V any] func(K, V) bool
+
+// Check calls c with k and v or returns true if c is nil.
+func (c EntryContainsFunc[K, V]) Check(k K, v V) bool {
+	if c == nil {
+		return true
+	}
+	return c(k, v)
+}
+
+// KeyedMergeFunc takes the key and two values of that key and merges them into a single value.
+// A nil KeyedMergeFunc always returns the lhs value.
+//
+// KeyedMergeFunc generalizes [MergeFunc] for when how to merge depends on the key.
+type KeyedMergeFunc[K comparable, V any] func(key K, lhs, rhs V) V
+
+// Into returns m(key, lhs, rhs) or lhs if m == nil.
+func (m KeyedMergeFunc[K, V]) Into(key K, lhs, rhs V) V {
+	if m == nil {
+		return lhs
+	}
+	return m(key, lhs, rhs)
+}
+
+// ContainsEntry is [Contains] with an [EntryContainsFunc].
+func ContainsEntry[K comparable, V any, M ~map[K]V](m M, key K, contains EntryContainsFunc[K, V]) bool {
+	v, ok := m[key]
+	if !ok {
+		return false
+	}
+	return contains.Check(key, v)
+}
+
+// UnionEntry is [Union] with an [EntryContainsFunc] and [KeyedMergeFunc].
+func UnionEntry[K comparable, V any, M ~map[K]V](lhs, rhs M, contains EntryContainsFunc[K, V], merge KeyedMergeFunc[K, V]) M {
+	return MergeWith(lhs, rhs, func(k K, l V, lok bool, r V, rok bool) (V, bool) {
+		lok = lok && contains.Check(k, l)
+		rok = rok && contains.Check(k, r)
+		if lok && rok {
+			// key exists in both maps, do a merge
+			v := merge.Into(k, l, r)
+			// make sure two valid entries aren't merged into an invalid entry
+			return v, contains.Check(k, v)
+		}
+		if lok {
+			return l, true
+		}
+		return r, rok
+	})
+}
+
+// IntersectEntry is [Intersect] with an [EntryContainsFunc] and [KeyedMergeFunc].
+func IntersectEntry[K comparable, V any, M ~map[K]V](lhs, rhs M, contains EntryContainsFunc[K, V], merge KeyedMergeFunc[K, V]) M {
+	out := make(M, min(len(lhs), len(rhs)))
+	intersectEntry(lhs, rhs, contains, merge, func(k K, v V) {
+		out[k] = v
+	})
+	return out
+}
+
+// intersectEntry is intersect with an EntryContainsFunc and KeyedMergeFunc.
+func intersectEntry[K comparable, V any, M ~map[K]V](lhs, rhs M, contains EntryContainsFunc[K, V], merge KeyedMergeFunc[K, V], f func(K, V)) {
+	if len(rhs) < len(lhs) {
+		for k, vp := range rhs {
+			v, ok := lhs[k]
+			if ok && contains.Check(k, v) && contains.Check(k, vp) {
+				vf := merge.Into(k, v, vp)
+				if contains.Check(k, vf) {
+					f(k, vf)
+				}
+			}
+		}
+		return
+	}
+	for k, v := range lhs {
+		vp, ok := rhs[k]
+		if ok && contains.Check(k, v) && contains.Check(k, vp) {
+			vf := merge.Into(k, v, vp)
+			if contains.Check(k, vf) {
+				f(k, vf)
+			}
+		}
+	}
+}
+
+// DiffEntry is [Diff] with an [EntryContainsFunc].
+func DiffEntry[K comparable, V any, M ~map[K]V](lhs, rhs M, contains EntryContainsFunc[K, V]) M {
+	out := make(M, len(lhs))
+	for k, v := range lhs {
+		if contains.Check(k, v) && !ContainsEntry(rhs, k, contains) {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// SymDiffEntry is [SymDiff] with an [EntryContainsFunc].
+func SymDiffEntry[K comparable, V any, M ~map[K]V](lhs, rhs M, contains EntryContainsFunc[K, V]) M {
+	return MergeWith(lhs, rhs, func(k K, l V, lok bool, r V, rok bool) (V, bool) {
+		lok = lok && contains.Check(k, l)
+		rok = rok && contains.Check(k, r)
+		if lok {
+			// k in lhs but not rhs
+			return l, !rok
+		}
+		// k in rhs but not lhs
+		return r, rok
+	})
+}
+
+// DisjointEntry is [Disjoint] with an [EntryContainsFunc].
+func DisjointEntry[K comparable, V any, M ~map[K]V](lhs, rhs M, contains EntryContainsFunc[K, V]) bool {
+	// disjointness is symmetric so only the smaller map needs to be iterated
+	if len(rhs) < len(lhs) {
+		lhs, rhs = rhs, lhs
+	}
+	for k, v := range lhs {
+		if contains.Check(k, v) && ContainsEntry(rhs, k, contains) {
+			return false
+		}
+	}
+	return true
+}
+
+// subsetEntry reports whether lhs is a subset of rhs and, if so, the number of items in lhs.
+func subsetEntry[K comparable, V any, M ~map[K]V](lhs, rhs M, contains EntryContainsFunc[K, V]) (int, bool) {
+	n := 0
+	for k, v := range lhs {
+		if !contains.Check(k, v) {
+			continue
+		}
+		if !ContainsEntry(rhs, k, contains) {
+			return 0, false
+		}
+		n++
+	}
+	return n, true
+}
+
+// EqualEntry is [Equal] with an [EntryContainsFunc].
+func EqualEntry[K comparable, V any, M ~map[K]V](lhs, rhs M, contains EntryContainsFunc[K, V]) bool {
+	n, ok := subsetEntry(lhs, rhs, contains)
+	return ok && n == LenEntry(rhs, contains)
+}
+
+// SubsetEntry is [Subset] with an [EntryContainsFunc].
+func SubsetEntry[K comparable, V any, M ~map[K]V](lhs, rhs M, contains EntryContainsFunc[K, V]) bool {
+	_, ok := subsetEntry(lhs, rhs, contains)
+	return ok
+}
+
+// ProperSubsetEntry is [ProperSubset] with an [EntryContainsFunc].
+func ProperSubsetEntry[K comparable, V any, M ~map[K]V](lhs, rhs M, contains EntryContainsFunc[K, V]) bool {
+	n, ok := subsetEntry(lhs, rhs, contains)
+	// lhs is a subset of rhs so rhs has extra items if and only if it is larger
+	return ok && n < LenEntry(rhs, contains)
+}
+
+// LenEntry is [Len] with an [EntryContainsFunc].
+func LenEntry[K comparable, V any, M ~map[K]V](m M, contains EntryContainsFunc[K, V]) int {
+	var n int
+	for k, v := range m {
+		if contains.Check(k, v) {
+			n++
+		}
+	}
+	return n
+}
+
+// UnionLenEntry is [UnionLen] with an [EntryContainsFunc] and [KeyedMergeFunc].
+func UnionLenEntry[K comparable, V any, M ~map[K]V](lhs, rhs M, contains EntryContainsFunc[K, V], merge KeyedMergeFunc[K, V]) int {
+	var n int
+	for k, v := range lhs {
+		if !contains.Check(k, v) {
+			continue
+		}
+		if vp, ok := rhs[k]; ok && contains.Check(k, vp) {
+			// key exists in both maps, only counted if the merge is valid
+			if contains.Check(k, merge.Into(k, v, vp)) {
+				n++
+			}
+			continue
+		}
+		n++
+	}
+	for k, v := range rhs {
+		if contains.Check(k, v) && !ContainsEntry(lhs, k, contains) {
+			n++
+		}
+	}
+	return n
+}
+
+// IntersectLenEntry is [IntersectLen] with an [EntryContainsFunc] and [KeyedMergeFunc].
+func IntersectLenEntry[K comparable, V any, M ~map[K]V](lhs, rhs M, contains EntryContainsFunc[K, V], merge KeyedMergeFunc[K, V]) int {
+	var n int
+	intersectEntry(lhs, rhs, contains, merge, func(K, V) {
+		n++
+	})
+	return n
+}
+
+// DiffLenEntry is [DiffLen] with an [EntryContainsFunc].
+func DiffLenEntry[K comparable, V any, M ~map[K]V](lhs, rhs M, contains EntryContainsFunc[K, V]) int {
+	var n int
+	for k, v := range lhs {
+		if contains.Check(k, v) && !ContainsEntry(rhs, k, contains) {
+			n++
+		}
+	}
+	return n
+}
+
+// SymDiffLenEntry is [SymDiffLen] with an [EntryContainsFunc].
+func SymDiffLenEntry[K comparable, V any, M ~map[K]V](lhs, rhs M, contains EntryContainsFunc[K, V]) int {
+	return DiffLenEntry(lhs, rhs, contains) + DiffLenEntry(rhs, lhs, contains)
+}
+
+// CloneEntry is [Clone] with an [EntryContainsFunc].
+func CloneEntry[K comparable, V any, M ~map[K]V](m M, contains EntryContainsFunc[K, V]) M {
+	out := make(M, len(m))
+	for k, v := range m {
+		if contains.Check(k, v) {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// KeysEntry is [Keys] with an [EntryContainsFunc].
+func KeysEntry[K comparable, V any, M ~map[K]V](m M, contains EntryContainsFunc[K, V]) []K {
+	var out []K
+	for k, v := range m {
+		if contains.Check(k, v) {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// ValuesEntry is [Values] with an [EntryContainsFunc].
+func ValuesEntry[K comparable, V any, M ~map[K]V](m M, contains EntryContainsFunc[K, V]) []V {
+	var out []V
+	for k, v := range m {
+		if contains.Check(k, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// PurgeEntry is [Purge] with an [EntryContainsFunc].
+func PurgeEntry[K comparable, V any, M ~map[K]V](m M, contains EntryContainsFunc[K, V]) {
+	if contains == nil {
+		return
+	}
+	for k, v := range m {
+		if !contains.Check(k, v) {
+			delete(m, k)
+		}
+	}
+}
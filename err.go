@@ -0,0 +1,206 @@
+package mapset
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ContainsFuncErr is a [ContainsFunc] that can fail.
+// A nil ContainsFuncErr always returns true.
+type ContainsFuncErr[V any] func(V) (bool, error)
+
+// Check calls c with v or returns true if c is nil.
+func (c ContainsFuncErr[V]) Check(v V) (bool, error) {
+	if c == nil {
+		return true, nil
+	}
+	return c(v)
+}
+
+// MergeFuncErr is a [MergeFunc] that can fail.
+// A nil MergeFuncErr always returns the lhs value.
+type MergeFuncErr[V any] func(lhs, rhs V) (V, error)
+
+// Into returns m(lhs, rhs) or lhs if m == nil.
+func (m MergeFuncErr[V]) Into(lhs, rhs V) (V, error) {
+	if m == nil {
+		return lhs, nil
+	}
+	return m(lhs, rhs)
+}
+
+// KeyError records the key whose value caused a [ContainsFuncErr] or [MergeFuncErr] to fail.
+type KeyError[K comparable] struct {
+	Key K
+	Err error
+}
+
+func (e *KeyError[K]) Error() string {
+	return fmt.Sprintf("key %v: %v", e.Key, e.Err)
+}
+
+func (e *KeyError[K]) Unwrap() error {
+	return e.Err
+}
+
+// OnError decides what the functions that accept a [ContainsFuncErr] or [MergeFuncErr] do when they fail.
+type OnError int
+
+const (
+	// StopOnError stops at the first failure and returns a nil map and its [KeyError].
+	StopOnError OnError = iota
+	// JoinErrors leaves each key that fails out of the result
+	// and returns the result and all the [KeyError]s combined with [errors.Join].
+	JoinErrors
+)
+
+// errs collects the errors of one operation according to an OnError.
+type errs[K comparable] struct {
+	onErr  OnError
+	failed map[K]struct{}
+	list   []error
+}
+
+// add records err for k and reports whether the operation should stop.
+func (e *errs[K]) add(k K, err error) bool {
+	if e.failed == nil {
+		e.failed = map[K]struct{}{}
+	}
+	e.failed[k] = struct{}{}
+	e.list = append(e.list, &KeyError[K]{k, err})
+	return e.onErr == StopOnError
+}
+
+// has reports whether k has already failed.
+func (e *errs[K]) has(k K) bool {
+	_, ok := e.failed[k]
+	return ok
+}
+
+// err returns the KeyError that stopped the operation or all of them joined.
+func (e *errs[K]) err() error {
+	if e.onErr == StopOnError && len(e.list) > 0 {
+		return e.list[0]
+	}
+	return errors.Join(e.list...)
+}
+
+// UnionErr is [Union] with a [ContainsFuncErr] and [MergeFuncErr].
+// Errors are handled according to onErr.
+func UnionErr[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFuncErr[V], merge MergeFuncErr[V], onErr OnError) (M, error) {
+	e := &errs[K]{onErr: onErr}
+	out := make(M, max(len(lhs), len(rhs)))
+	for k, v := range lhs {
+		ok, err := contains.Check(v)
+		if err != nil {
+			if e.add(k, err) {
+				return nil, e.err()
+			}
+			continue
+		}
+		if ok {
+			out[k] = v
+		}
+	}
+	for k, v := range rhs {
+		if e.has(k) {
+			continue
+		}
+		ok, err := contains.Check(v)
+		if err != nil {
+			delete(out, k)
+			if e.add(k, err) {
+				return nil, e.err()
+			}
+			continue
+		}
+		if !ok {
+			continue
+		}
+		L, ok := out[k]
+		if !ok {
+			// new key, add
+			out[k] = v
+			continue
+		}
+		// key exists in both maps, do a merge
+		v, err := merge.Into(L, v)
+		if err == nil {
+			ok, err = contains.Check(v)
+		}
+		if err != nil {
+			delete(out, k)
+			if e.add(k, err) {
+				return nil, e.err()
+			}
+			continue
+		}
+		out[k] = v
+		// make sure two valid entries aren't merged into an invalid entry
+		if !ok {
+			delete(out, k)
+		}
+	}
+	return out, e.err()
+}
+
+// IntersectErr is [Intersect] with a [ContainsFuncErr] and [MergeFuncErr].
+// Errors are handled according to onErr.
+func IntersectErr[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFuncErr[V], merge MergeFuncErr[V], onErr OnError) (M, error) {
+	e := &errs[K]{onErr: onErr}
+	out := make(M, min(len(lhs), len(rhs)))
+	for k, v := range lhs {
+		vp, ok := rhs[k]
+		if !ok {
+			continue
+		}
+		vf, ok, err := intersectErr(v, vp, contains, merge)
+		if err != nil {
+			if e.add(k, err) {
+				return nil, e.err()
+			}
+			continue
+		}
+		if ok {
+			out[k] = vf
+		}
+	}
+	return out, e.err()
+}
+
+// intersectErr checks v and vp, merges them, and checks the merged value.
+func intersectErr[V any](v, vp V, contains ContainsFuncErr[V], merge MergeFuncErr[V]) (V, bool, error) {
+	var zero V
+	for _, x := range [...]V{v, vp} {
+		ok, err := contains.Check(x)
+		if err != nil || !ok {
+			return zero, false, err
+		}
+	}
+	vf, err := merge.Into(v, vp)
+	if err != nil {
+		return zero, false, err
+	}
+	ok, err := contains.Check(vf)
+	return vf, ok, err
+}
+
+// CloneErr is [Clone] with a [ContainsFuncErr].
+// Errors are handled according to onErr.
+func CloneErr[K comparable, V any, M ~map[K]V](m M, contains ContainsFuncErr[V], onErr OnError) (M, error) {
+	e := &errs[K]{onErr: onErr}
+	out := make(M, len(m))
+	for k, v := range m {
+		ok, err := contains.Check(v)
+		if err != nil {
+			if e.add(k, err) {
+				return nil, e.err()
+			}
+			continue
+		}
+		if ok {
+			out[k] = v
+		}
+	}
+	return out, e.err()
+}
@@ -1,8 +1,12 @@
 package mapset_test
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/jimmyfrasche/mapset"
@@ -258,3 +262,1026 @@ func ExamplePurge() {
 	// nil contains: map[a:-1 b:0 c:1]
 	// nonnil contains: map[b:0 c:1]
 }
+
+func ExampleAll() {
+	m := map[string]int{"a": -1, "b": 0, "c": 1}
+
+	contains := func(n int) bool {
+		return n >= 0
+	}
+
+	// "a" is skipped as -1 fails the contains check
+	fmt.Println(maps.Collect(mapset.All(m, contains)))
+
+	// Output:
+	// map[b:0 c:1]
+}
+
+func ExampleKeysSeq() {
+	m := map[string]bool{"a": true, "b": true, "c": false, "d": true}
+
+	contains := func(b bool) bool {
+		return b
+	}
+
+	fmt.Println("k:", slices.Sorted(mapset.KeysSeq(m, contains)))
+
+	// Output:
+	// k: [a b d]
+}
+
+func ExampleValuesSeq() {
+	m := map[int]string{0: "a", 1: "b", 2: "c"}
+
+	contains := func(s string) bool {
+		return s != "a"
+	}
+
+	fmt.Println("v:", slices.Sorted(mapset.ValuesSeq(m, contains)))
+
+	// Output:
+	// v: [b c]
+}
+
+func ExampleFromSeq() {
+	x := map[string]int{"a": 1, "b": 2}
+	y := map[string]int{"b": 3, "c": 0}
+
+	contains := func(n int) bool {
+		return n != 0
+	}
+
+	merge := func(a, b int) int {
+		return a + b
+	}
+
+	z := mapset.FromSeq[map[string]int](maps.All(x), contains, merge)
+	mapset.CollectInto(z, maps.All(y), contains, merge)
+
+	fmt.Println(z)
+
+	// Output:
+	// map[a:1 b:5]
+}
+
+func TestCollectIntoPurges(t *testing.T) {
+	dst := map[string]int{"a": 0, "b": 1}
+	contains := func(n int) bool {
+		return n != 0
+	}
+
+	mapset.CollectInto(dst, maps.All(map[string]int{}), contains, nil)
+	if !maps.Equal(dst, map[string]int{"b": 1}) {
+		t.Fatalf("got %v, want map[b:1]", dst)
+	}
+}
+
+func ExampleSet_All() {
+	s := mapset.Set[string]{}
+	s.ExtendSeq(slices.Values([]string{"c", "a", "b", "a"}))
+
+	fmt.Println(slices.Sorted(s.All()))
+
+	// Output:
+	// [a b c]
+}
+
+func ExampleBool_All() {
+	b := mapset.Bool[string]{"a": true, "b": false, "c": true}
+
+	fmt.Println(slices.Sorted(b.All()))
+
+	// Output:
+	// [a c]
+}
+
+func ExampleUnionSeq() {
+	x := map[string]int{"a": 0, "b": 1, "d": -5}
+	y := map[string]int{"b": 2, "c": 1, "d": 5}
+
+	contains := func(n int) bool {
+		return n != 0
+	}
+
+	merge := func(a, b int) int {
+		return a + b
+	}
+
+	// the same rules as Union apply, see ExampleUnion
+	fmt.Println(maps.Collect(mapset.UnionSeq(x, y, contains, merge)))
+
+	// Output:
+	// map[b:3 c:1]
+}
+
+func ExampleIntersectSeq() {
+	x := map[string]int{"a": 0, "b": 1, "d": -5}
+	y := map[string]int{"b": 2, "c": 1, "d": 5}
+
+	fmt.Println(maps.Collect(mapset.IntersectSeq(x, y, nil, nil)))
+
+	// Output:
+	// map[b:1 d:-5]
+}
+
+func ExampleDiffSeq() {
+	x := map[string]int{"a": 0, "b": 1, "d": -5}
+	y := map[string]int{"b": 2, "c": 1, "d": 5}
+
+	fmt.Println(maps.Collect(mapset.DiffSeq(x, y, nil)))
+
+	// Output:
+	// map[a:0]
+}
+
+func ExampleSymDiffSeq() {
+	x := map[string]int{"a": 0, "b": 1, "d": -5}
+	y := map[string]int{"b": 2, "c": 1, "d": 5, "e": 7}
+
+	contains := func(n int) bool {
+		return n >= 0
+	}
+
+	fmt.Println(maps.Collect(mapset.SymDiffSeq(x, y, contains)))
+
+	// Output:
+	// map[a:0 c:1 d:5 e:7]
+}
+
+func ExampleUnionInto() {
+	x := map[string]int{"a": 0, "b": 1, "d": -5}
+	y := map[string]int{"b": 2, "c": 1, "d": 5}
+
+	contains := func(n int) bool {
+		return n != 0
+	}
+
+	merge := func(a, b int) int {
+		return a + b
+	}
+
+	// x ends up the same as the z of ExampleUnion
+	mapset.UnionInto(x, y, contains, merge)
+	fmt.Println(x)
+
+	// Output:
+	// map[b:3 c:1]
+}
+
+func ExampleIntersectInPlace() {
+	x := map[string]int{"a": 0, "b": 1, "d": -5}
+	y := map[string]int{"b": 2, "c": 1, "d": 5}
+
+	mapset.IntersectInPlace(x, y, nil, nil)
+	fmt.Println(x)
+
+	// Output:
+	// map[b:1 d:-5]
+}
+
+func ExampleDiffInPlace() {
+	x := map[string]int{"a": 0, "b": 1, "d": -5}
+	y := map[string]int{"b": 2, "c": 1, "d": 5}
+
+	mapset.DiffInPlace(x, y, nil)
+	fmt.Println(x)
+
+	// Output:
+	// map[a:0]
+}
+
+func ExampleSymDiffInPlace() {
+	x := map[string]int{"a": 0, "b": 1, "d": -5}
+	y := map[string]int{"b": 2, "c": 1, "d": 5, "e": 7}
+
+	contains := func(n int) bool {
+		return n >= 0
+	}
+
+	mapset.SymDiffInPlace(x, y, contains)
+	fmt.Println(x)
+
+	// Output:
+	// map[a:0 c:1 d:5 e:7]
+}
+
+func ExampleUnionAll() {
+	x := map[string]int{"a": 1, "b": 1}
+	y := map[string]int{"b": 2, "c": 1}
+	z := map[string]int{"c": -1, "d": 3}
+
+	contains := func(n int) bool {
+		return n != 0
+	}
+
+	merge := func(a, b int) int {
+		return a + b
+	}
+
+	// "c" is merged into 0 by z, which fails the contains check
+	fmt.Println(mapset.UnionAll(contains, merge, x, y, z))
+
+	// Output:
+	// map[a:1 b:3 d:3]
+}
+
+func ExampleIntersectAll() {
+	x := map[string]int{"a": 1, "b": 1, "c": 1}
+	y := map[string]int{"b": 2, "c": 2}
+	z := map[string]int{"a": 3, "b": 3, "c": 3, "d": 3}
+
+	merge := func(a, b int) int {
+		return a*10 + b
+	}
+
+	// values are merged in argument order: ((x[k], y[k]), z[k])
+	fmt.Println(mapset.IntersectAll(nil, merge, x, y, z))
+
+	// Output:
+	// map[b:123 c:123]
+}
+
+func ExampleDiffAll() {
+	x := map[string]int{"a": 0, "b": 1, "c": 2, "d": 3}
+	y := map[string]int{"b": 0}
+	z := map[string]int{"d": 0}
+
+	fmt.Println(mapset.DiffAll(x, nil, y, z))
+
+	// Output:
+	// map[a:0 c:2]
+}
+
+func TestLenOps(t *testing.T) {
+	x := map[string]int{"a": 0, "b": 1, "d": -5, "e": 2}
+	y := map[string]int{"b": 2, "c": 1, "d": 5}
+
+	contains := func(n int) bool {
+		return n != 0
+	}
+
+	merge := func(a, b int) int {
+		return a + b
+	}
+
+	for _, c := range []mapset.ContainsFunc[int]{nil, contains} {
+		for _, m := range []mapset.MergeFunc[int]{nil, merge} {
+			for _, p := range [][2]map[string]int{{x, y}, {y, x}} {
+				l, r := p[0], p[1]
+				if got, want := mapset.UnionLen(l, r, c, m), len(mapset.Union(l, r, c, m)); got != want {
+					t.Errorf("UnionLen = %d, want %d", got, want)
+				}
+				if got, want := mapset.IntersectLen(l, r, c, m), len(mapset.Intersect(l, r, c, m)); got != want {
+					t.Errorf("IntersectLen = %d, want %d", got, want)
+				}
+				if got, want := mapset.DiffLen(l, r, c), len(mapset.Diff(l, r, c)); got != want {
+					t.Errorf("DiffLen = %d, want %d", got, want)
+				}
+				if got, want := mapset.SymDiffLen(l, r, c), len(mapset.SymDiff(l, r, c)); got != want {
+					t.Errorf("SymDiffLen = %d, want %d", got, want)
+				}
+			}
+		}
+	}
+}
+
+func ExampleUnionStrict() {
+	x := map[string]int{"a": 0, "b": 1, "d": -5}
+	y := map[string]int{"b": 1, "c": 1, "d": 5}
+
+	equal := func(a, b int) bool {
+		return a == b
+	}
+
+	z, conflicts := mapset.UnionStrict(x, y, nil, equal)
+	fmt.Println("z:", z)
+	for _, c := range conflicts {
+		fmt.Printf("conflict: %s: %d ≠ %d\n", c.Key, c.Lhs, c.Rhs)
+	}
+
+	// Output:
+	// z: map[a:0 b:1 c:1 d:-5]
+	// conflict: d: -5 ≠ 5
+}
+
+func ExampleUnionErr() {
+	x := map[string]int{"a": 1, "b": 1}
+	y := map[string]int{"b": 2, "c": 3}
+
+	errOdd := errors.New("odd sum")
+	merge := func(a, b int) (int, error) {
+		if (a+b)%2 != 0 {
+			return 0, errOdd
+		}
+		return a + b, nil
+	}
+
+	_, err := mapset.UnionErr(x, y, nil, merge, mapset.StopOnError)
+	fmt.Println("error:", err)
+
+	var kerr *mapset.KeyError[string]
+	if errors.As(err, &kerr) && errors.Is(err, errOdd) {
+		fmt.Println("failed key:", kerr.Key)
+	}
+
+	// with JoinErrors, the failed key is left out of the result
+	z, err := mapset.UnionErr(x, y, nil, merge, mapset.JoinErrors)
+	fmt.Println("z:", z)
+	fmt.Println("error:", err)
+
+	// Output:
+	// error: key b: odd sum
+	// failed key: b
+	// z: map[a:1 c:3]
+	// error: key b: odd sum
+}
+
+func ExampleCloneErr() {
+	m := map[string]int{"a": -1, "b": 0, "c": 1}
+
+	contains := func(n int) (bool, error) {
+		if n < 0 {
+			return false, fmt.Errorf("negative: %d", n)
+		}
+		return n > 0, nil
+	}
+
+	z, err := mapset.CloneErr(m, contains, mapset.JoinErrors)
+	fmt.Println("z:", z)
+	fmt.Println("error:", err)
+
+	// Output:
+	// z: map[c:1]
+	// error: key a: negative: -1
+}
+
+func TestIntersectErr(t *testing.T) {
+	x := map[string]int{"a": -1, "b": 0, "c": 1, "d": 2}
+	y := map[string]int{"a": 1, "b": 1, "c": 1, "e": 1}
+
+	contains := func(n int) (bool, error) {
+		if n < 0 {
+			return false, fmt.Errorf("negative: %d", n)
+		}
+		return n > 0, nil
+	}
+
+	z, err := mapset.IntersectErr(x, y, contains, nil, mapset.StopOnError)
+	if err == nil || z != nil {
+		t.Fatal("StopOnError must return a nil map and an error")
+	}
+	if ke, ok := err.(*mapset.KeyError[string]); !ok || ke.Key != "a" {
+		t.Fatalf("StopOnError: got %#v, want a *KeyError for a", err)
+	}
+
+	z, err = mapset.IntersectErr(x, y, contains, nil, mapset.JoinErrors)
+	if err == nil {
+		t.Fatal("JoinErrors must return an error")
+	}
+	if len(z) != 1 || z["c"] != 1 {
+		t.Fatalf("got %v, want map[c:1]", z)
+	}
+
+	z, err = mapset.IntersectErr(y, y, contains, nil, mapset.StopOnError)
+	if err != nil || len(z) != len(y) {
+		t.Fatalf("got %v, %v, want %v, nil", z, err, y)
+	}
+}
+
+func ExampleUnionEntry() {
+	x := map[string]int{"x/a": 1, "x/b": 2, "c": 3, "tmp/d": 4}
+	y := map[string]int{"x/a": 10, "c": 30, "e": 5}
+
+	// keys under tmp/ are never in the set
+	contains := func(k string, _ int) bool {
+		return !strings.HasPrefix(k, "tmp/")
+	}
+
+	// keys under x/ are summed, other keys keep the lhs value
+	merge := func(k string, a, b int) int {
+		if strings.HasPrefix(k, "x/") {
+			return a + b
+		}
+		return a
+	}
+
+	fmt.Println(mapset.UnionEntry(x, y, contains, merge))
+
+	// Output:
+	// map[c:3 e:5 x/a:11 x/b:2]
+}
+
+func ExampleEqualEntry() {
+	a := map[string]int{"a": 1, "tmp/b": 2}
+	b := map[string]int{"a": 3}
+
+	contains := func(k string, _ int) bool {
+		return !strings.HasPrefix(k, "tmp/")
+	}
+
+	fmt.Println("nil contains:", mapset.EqualEntry(a, b, nil))
+	fmt.Println("ignoring tmp/:", mapset.EqualEntry(a, b, contains))
+
+	// Output:
+	// nil contains: false
+	// ignoring tmp/: true
+}
+
+func ExampleMergeWith() {
+	type entry struct {
+		value   string
+		version int
+	}
+
+	x := map[string]entry{"a": {"a1", 1}, "b": {"b1", 1}, "c": {"c2", 2}}
+	y := map[string]entry{"b": {"b2", 2}, "c": {"c1", 1}, "d": {"d1", 1}}
+
+	// update the keys of x only if the entry in y is newer
+	newer := func(_ string, l entry, lok bool, r entry, rok bool) (entry, bool) {
+		if lok && rok && r.version > l.version {
+			return r, true
+		}
+		return l, lok
+	}
+
+	z := mapset.MergeWith(x, y, newer)
+	for _, k := range slices.Sorted(maps.Keys(z)) {
+		fmt.Println(k, z[k].value)
+	}
+
+	// Output:
+	// a a1
+	// b b2
+	// c c2
+}
+
+func ExampleMergeWithInPlace() {
+	x := map[string]int{"a": 1, "b": 2}
+	y := map[string]int{"b": 3, "c": 4}
+
+	// a full outer merge that sums the values and drops keys only in x
+	f := func(_ string, l int, lok bool, r int, rok bool) (int, bool) {
+		return l + r, rok
+	}
+
+	mapset.MergeWithInPlace(x, y, f)
+	fmt.Println(x)
+
+	// Output:
+	// map[b:5 c:4]
+}
+
+func ExampleRestrict() {
+	ages := map[string]int{"ann": 31, "bob": 42, "cy": 27}
+	active := mapset.Set[string]{"ann": {}, "cy": {}, "dee": {}}
+
+	fmt.Println(mapset.Restrict(ages, nil, active, nil))
+
+	// Output:
+	// map[ann:31 cy:27]
+}
+
+func ExampleWithout() {
+	ages := map[string]int{"ann": 31, "bob": 42, "cy": 27}
+	banned := mapset.Bool[string]{"ann": false, "bob": true}
+
+	// NonZero skips ann, matching the behavior of Bool's methods
+	fmt.Println(mapset.Without(ages, nil, banned, mapset.NonZero[bool]))
+
+	// Output:
+	// map[ann:31 cy:27]
+}
+
+func ExampleSubsetKeys() {
+	ages := map[string]int{"ann": 31, "cy": 27}
+	names := map[string]string{"ann": "Ann", "bob": "Bob", "cy": "Cy"}
+
+	fmt.Println("ages ⊆ names?", mapset.SubsetKeys(ages, nil, names, nil))
+	fmt.Println("names ⊆ ages?", mapset.SubsetKeys(names, nil, ages, nil))
+	fmt.Println("ages = names?", mapset.EqualKeys(ages, nil, names, nil))
+	fmt.Println("ages ∩ names = ∅?", mapset.DisjointKeys(ages, nil, names, nil))
+
+	// Output:
+	// ages ⊆ names? true
+	// names ⊆ ages? false
+	// ages = names? false
+	// ages ∩ names = ∅? false
+}
+
+func ExampleEqualFunc() {
+	a := map[string]string{"a": "x", "b": "Y", "c": ""}
+	b := map[string]string{"a": "X", "b": "y"}
+
+	contains := func(s string) bool {
+		return s != ""
+	}
+
+	fmt.Println("same case:", mapset.EqualFunc(a, b, contains, func(x, y string) bool {
+		return x == y
+	}))
+	fmt.Println("any case:", mapset.EqualFunc(a, b, contains, strings.EqualFold))
+
+	// Output:
+	// same case: false
+	// any case: true
+}
+
+func ExampleCompare() {
+	a := map[string]int{"a": 1, "b": 2}
+	b := map[string]int{"a": 1, "b": 2, "c": 3}
+	c := map[string]int{"c": 3, "d": 4}
+
+	fmt.Println("a, a:", mapset.Compare(a, a, nil))
+	fmt.Println("a, b:", mapset.Compare(a, b, nil))
+	fmt.Println("b, a:", mapset.Compare(b, a, nil))
+	fmt.Println("a, c:", mapset.Compare(a, c, nil))
+	fmt.Println("b, c:", mapset.Compare(b, c, nil))
+
+	// Output:
+	// a, a: equal
+	// a, b: proper subset
+	// b, a: proper superset
+	// a, c: disjoint
+	// b, c: overlapping
+}
+
+func TestEqualSkipsFailedItems(t *testing.T) {
+	a := map[string]int{"a": 1, "x": -1}
+	b := map[string]int{"a": 2}
+
+	contains := func(n int) bool {
+		return n >= 0
+	}
+
+	if !mapset.Equal(a, b, contains) || !mapset.Equal(b, a, contains) {
+		t.Fatal("items that fail the contains check must not affect equality")
+	}
+	if mapset.ProperSubset(b, a, contains) {
+		t.Fatal("b is not a proper subset of a once a[x] is skipped")
+	}
+	if mapset.EqualValues(a, b, contains) {
+		t.Fatal("values of a and b differ")
+	}
+	if !mapset.EqualFunc(a, b, contains, nil) {
+		t.Fatal("a nil eq must compare only the keys")
+	}
+	if r := mapset.Compare(a, b, contains); r != mapset.RelEqual {
+		t.Fatalf("Compare(a, b) = %v, want equal", r)
+	}
+}
+
+func ExampleDiffReport() {
+	before := map[string]int{"a": 1, "b": 2, "c": 3}
+	after := map[string]int{"b": 2, "c": 4, "d": 5}
+
+	eq := func(x, y int) bool {
+		return x == y
+	}
+
+	d := mapset.DiffReport(before, after, nil, eq)
+	fmt.Println("added:", d.Added)
+	fmt.Println("removed:", d.Removed)
+	fmt.Println("changed:", d.Changed)
+
+	// replaying d turns before into after and its inverse rolls it back
+	m := maps.Clone(before)
+	mapset.Apply(m, d)
+	fmt.Println("applied:", m)
+
+	mapset.Apply(m, mapset.Invert(d))
+	fmt.Println("rolled back:", m)
+
+	// Output:
+	// added: map[d:5]
+	// removed: map[a:1]
+	// changed: map[c:{3 4}]
+	// applied: map[b:2 c:4 d:5]
+	// rolled back: map[a:1 b:2 c:3]
+}
+
+func TestCompose(t *testing.T) {
+	eq := func(x, y int) bool {
+		return x == y
+	}
+
+	m0 := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5}
+	m1 := map[string]int{"a": 10, "b": 2, "d": 40, "f": 6, "g": 7}
+	m2 := map[string]int{"a": 1, "c": 30, "d": 41, "f": 60, "h": 8}
+
+	d1 := mapset.DiffReport(m0, m1, nil, eq)
+	d2 := mapset.DiffReport(m1, m2, nil, eq)
+	d := mapset.Compose(d1, d2, eq)
+
+	want := mapset.DiffReport(m0, m2, nil, eq)
+	if !maps.Equal(d.Added, want.Added) || !maps.Equal(d.Removed, want.Removed) || !maps.Equal(d.Changed, want.Changed) {
+		t.Fatalf("Compose = %v, want %v", d, want)
+	}
+
+	m := maps.Clone(m0)
+	mapset.Apply(m, d)
+	if !maps.Equal(m, m2) {
+		t.Fatalf("Apply(m0, d) = %v, want %v", m, m2)
+	}
+
+	mapset.Apply(m, mapset.Invert(d))
+	if !maps.Equal(m, m0) {
+		t.Fatalf("Apply(m2, Invert(d)) = %v, want %v", m, m0)
+	}
+
+	// a nil eq never drops a change, so changed twice and removed then added back are kept
+	for _, ms := range [][3]map[string]int{
+		{{"a": 1}, {"a": 2}, {"a": 3}},
+		{{"a": 1}, {}, {"a": 5}},
+	} {
+		d := mapset.Compose(mapset.DiffReport(ms[0], ms[1], nil, eq), mapset.DiffReport(ms[1], ms[2], nil, eq), nil)
+		m := maps.Clone(ms[0])
+		mapset.Apply(m, d)
+		if !maps.Equal(m, ms[2]) {
+			t.Fatalf("Apply(%v, Compose(d1, d2, nil)) = %v, want %v", ms[0], m, ms[2])
+		}
+	}
+}
+
+func ExampleMerge3() {
+	base := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5}
+	ours := map[string]int{"a": 10, "b": 2, "c": 30, "e": 5, "f": 6}
+	theirs := map[string]int{"a": 1, "b": 20, "c": 31, "d": 4, "e": 50}
+
+	eq := func(x, y int) bool {
+		return x == y
+	}
+
+	// "a" and "f" are only changed by ours,
+	// "b" is only changed by theirs,
+	// "d" is only removed by ours,
+	// "c" is edited by both and is resolved by taking the larger value
+	resolve := func(o, t int) int {
+		return max(o, t)
+	}
+
+	m, conflicts := mapset.Merge3(base, ours, theirs, nil, eq, resolve)
+	fmt.Println("merged:", m)
+	fmt.Println("conflicts:", conflicts)
+
+	// Output:
+	// merged: map[a:10 b:20 c:31 e:50 f:6]
+	// conflicts: [c]
+}
+
+func TestMerge3DeleteEdit(t *testing.T) {
+	base := map[string]int{"a": 1, "b": 2}
+	ours := map[string]int{"a": 10}
+	theirs := map[string]int{"b": 20}
+
+	eq := func(x, y int) bool {
+		return x == y
+	}
+
+	m, conflicts := mapset.Merge3(base, ours, theirs, nil, eq, nil)
+	want := map[string]int{"a": 10, "b": 20}
+	if !maps.Equal(m, want) {
+		t.Fatalf("got %v, want %v", m, want)
+	}
+	slices.Sort(conflicts)
+	if !slices.Equal(conflicts, []string{"a", "b"}) {
+		t.Fatalf("got conflicts %v, want [a b]", conflicts)
+	}
+}
+
+func TestMerge3Removals(t *testing.T) {
+	base := map[string]int{"a": 1, "b": 2, "c": 3}
+	ours := map[string]int{"a": 1, "b": 5}
+
+	eq := func(x, y int) bool {
+		return x == y
+	}
+
+	// merging against an untouched theirs gives back ours
+	m, conflicts := mapset.Merge3(base, ours, base, nil, eq, nil)
+	if !maps.Equal(m, ours) || len(conflicts) != 0 {
+		t.Fatalf("got %v, %v, want %v and no conflicts", m, conflicts, ours)
+	}
+
+	// a removal made by only one side is taken
+	m, conflicts = mapset.Merge3(base, map[string]int{"a": 1, "b": 2}, map[string]int{"b": 2, "c": 3}, nil, eq, nil)
+	if want := map[string]int{"b": 2}; !maps.Equal(m, want) || len(conflicts) != 0 {
+		t.Fatalf("got %v, %v, want %v and no conflicts", m, conflicts, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Merge3 with a nil eq did not panic")
+		}
+	}()
+	mapset.Merge3(base, ours, base, nil, nil, nil)
+}
+
+func ExampleReconcile() {
+	desired := map[string]int{"a": 1, "b": 2, "c": 3}
+	actual := map[string]int{"b": 20, "c": 3, "d": 4}
+
+	eq := func(x, y int) bool {
+		return x == y
+	}
+
+	h := mapset.Handlers[string, int]{
+		OnAdd: func(k string, v int) error {
+			fmt.Println("create", k, v)
+			return nil
+		},
+		OnUpdate: func(k string, desired, actual int) error {
+			fmt.Println("update", k, actual, "→", desired)
+			return nil
+		},
+		OnRemove: func(k string, v int) error {
+			fmt.Println("delete", k)
+			return nil
+		},
+	}
+
+	opts := mapset.ReconcileOptions[string]{
+		Compare: strings.Compare,
+	}
+
+	if _, err := mapset.Reconcile(desired, actual, nil, eq, h, opts); err != nil {
+		fmt.Println(err)
+	}
+
+	// the plan can be inspected without running it
+	opts.DryRun = true
+	plan, _ := mapset.Reconcile(desired, actual, nil, eq, h, opts)
+	for _, s := range plan {
+		fmt.Println("plan:", s.Action, s.Key)
+	}
+
+	// Output:
+	// delete d
+	// update b 20 → 2
+	// create a 1
+	// plan: remove d
+	// plan: update b
+	// plan: add a
+}
+
+func TestReconcileErrors(t *testing.T) {
+	desired := map[string]int{"a": 1, "b": 2}
+	actual := map[string]int{}
+
+	errFail := errors.New("fail")
+	calls := 0
+	h := mapset.Handlers[string, int]{
+		OnAdd: func(string, int) error {
+			calls++
+			return errFail
+		},
+	}
+
+	_, err := mapset.Reconcile(desired, actual, nil, nil, h, mapset.ReconcileOptions[string]{})
+	if !errors.Is(err, errFail) || calls != 1 {
+		t.Fatalf("StopOnError: got %v after %d calls, want errFail after 1 call", err, calls)
+	}
+	if _, ok := err.(*mapset.KeyError[string]); !ok {
+		t.Fatalf("StopOnError: got %#v, want a *KeyError", err)
+	}
+
+	calls = 0
+	_, err = mapset.Reconcile(desired, actual, nil, nil, h, mapset.ReconcileOptions[string]{OnError: mapset.JoinErrors})
+	if !errors.Is(err, errFail) || calls != 2 {
+		t.Fatalf("JoinErrors: got %v after %d calls, want errFail after 2 calls", err, calls)
+	}
+}
+
+func TestReconcileNilEq(t *testing.T) {
+	desired := map[string]int{"a": 1, "b": 2}
+	actual := map[string]int{"a": 1, "b": 20}
+
+	// a nil eq treats all values as unequal, so every key in both maps is updated
+	var updated []string
+	h := mapset.Handlers[string, int]{
+		OnUpdate: func(k string, desired, actual int) error {
+			updated = append(updated, k)
+			return nil
+		},
+	}
+	if _, err := mapset.Reconcile(desired, actual, nil, nil, h, mapset.ReconcileOptions[string]{Compare: strings.Compare}); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(updated, []string{"a", "b"}) {
+		t.Fatalf("updated %v, want [a b]", updated)
+	}
+}
+
+func ExampleAnd() {
+	m := map[string]int{"a": -5, "b": 0, "c": 5, "d": 50}
+
+	small := mapset.And(mapset.NonZero[int], mapset.LessThan(10))
+	fmt.Println("small:", mapset.Clone(m, small))
+
+	large := mapset.Not(small)
+	fmt.Println("large:", mapset.Clone(m, large))
+
+	fmt.Println("either:", mapset.Clone(m, mapset.Or(mapset.GreaterThan(10), mapset.LessThan(0))))
+	fmt.Println("only one:", mapset.Clone(m, mapset.Xor(mapset.GreaterThan(0), mapset.NonZero[int])))
+
+	// Output:
+	// small: map[a:-5 c:5]
+	// large: map[b:0 d:50]
+	// either: map[a:-5 d:50]
+	// only one: map[a:-5]
+}
+
+func ExampleValueIn() {
+	m := map[int]string{1: "red", 2: "green", 3: "blue"}
+	warm := mapset.Set[string]{"red": {}, "orange": {}}
+
+	fmt.Println(mapset.Clone(m, mapset.ValueIn(warm)))
+
+	// Output:
+	// map[1:red]
+}
+
+func ExampleMemoize() {
+	calls := 0
+	slow := func(s string) bool {
+		calls++
+		return len(s) > 1
+	}
+
+	m := map[int]string{1: "a", 2: "bb", 3: "a", 4: "bb", 5: "ccc"}
+	fmt.Println(mapset.Len(m, mapset.Memoize(slow)))
+	fmt.Println("calls:", calls)
+
+	// Output:
+	// 3
+	// calls: 3
+}
+
+func TestCombinatorNils(t *testing.T) {
+	if mapset.And[int]() != nil || mapset.And[int](nil, nil) != nil {
+		t.Fatal("And of nils must be nil")
+	}
+	if mapset.Or(mapset.Never[int], nil) != nil {
+		t.Fatal("Or containing nil must be nil")
+	}
+	if mapset.Or[int]().Check(0) {
+		t.Fatal("Or of nothing must reject")
+	}
+	if mapset.Not[int](nil).Check(0) {
+		t.Fatal("Not(nil) must reject")
+	}
+	if !mapset.Xor[int](nil).Check(0) || mapset.Xor[int](nil, nil).Check(0) {
+		t.Fatal("Xor must treat nil as always true")
+	}
+	if mapset.Memoize[int](nil) != nil {
+		t.Fatal("Memoize(nil) must be nil")
+	}
+}
+
+func ExampleSum() {
+	x := map[string]int{"a": 1, "b": 2}
+	y := map[string]int{"b": 3, "c": 4}
+
+	fmt.Println("sum:", mapset.Union(x, y, nil, mapset.Sum[int]))
+	fmt.Println("min:", mapset.Union(x, y, nil, mapset.Min[int]))
+	fmt.Println("max:", mapset.Union(x, y, nil, mapset.Max[int]))
+	fmt.Println("right:", mapset.Union(x, y, nil, mapset.Right[int]))
+
+	// Output:
+	// sum: map[a:1 b:5 c:4]
+	// min: map[a:1 b:2 c:4]
+	// max: map[a:1 b:3 c:4]
+	// right: map[a:1 b:3 c:4]
+}
+
+func ExampleAppendUnique() {
+	x := map[string][]int{"a": {1, 2}, "b": {3}}
+	y := map[string][]int{"a": {2, 3}}
+
+	fmt.Println("append:", mapset.Union(x, y, nil, mapset.AppendSlices[[]int]))
+	fmt.Println("unique:", mapset.Union(x, y, nil, mapset.AppendUnique[[]int]))
+
+	// Output:
+	// append: map[a:[1 2 2 3] b:[3]]
+	// unique: map[a:[1 2 3] b:[3]]
+}
+
+func TestAppendUnique(t *testing.T) {
+	x := []int{1, 1, 2}
+	if got := mapset.AppendUnique(x, x); !slices.Equal(got, x) {
+		t.Fatalf("AppendUnique(x, x) = %v, want %v", got, x)
+	}
+	if got := mapset.AppendUnique(x, []int{3, 2, 3, 4}); !slices.Equal(got, []int{1, 1, 2, 3, 4}) {
+		t.Fatalf("got %v, want [1 1 2 3 4]", got)
+	}
+}
+
+func ExampleMergeMaps() {
+	type inner = map[string]int
+
+	x := map[string]inner{"a": {"x": 1}, "b": {"y": 2}}
+	y := map[string]inner{"a": {"x": 10, "z": 3}}
+
+	merge := mapset.MergeMaps[inner](mapset.Sum[int])
+	fmt.Println(mapset.Union(x, y, nil, merge))
+
+	// Output:
+	// map[a:map[x:11 z:3] b:map[y:2]]
+}
+
+func ExampleChain() {
+	x := map[string]string{"a": "", "b": "", "c": "left"}
+	y := map[string]string{"a": "", "b": "right", "c": "right"}
+
+	// prefer lhs unless it is empty, then rhs, and fall back to a default
+	orDefault := func(string, string) string {
+		return "default"
+	}
+	merge := mapset.Chain(mapset.FirstNonZero[string], orDefault)
+
+	fmt.Println(mapset.Union(x, y, nil, merge))
+
+	// Output:
+	// map[a:default b:right c:left]
+}
+
+func ExampleDeepMerge() {
+	base := map[string]any{
+		"name": "app",
+		"server": map[string]any{
+			"port":  8080,
+			"hosts": []any{"a", "b"},
+			"debug": true,
+		},
+	}
+	overlay := map[string]any{
+		"server": map[string]any{
+			"port":  9090,
+			"hosts": []any{"b", "c"},
+			"debug": nil,
+		},
+	}
+
+	opts := mapset.DeepMergeOptions{
+		Slices:   mapset.SliceUnion,
+		Contains: mapset.NonNil,
+	}
+
+	m, err := mapset.DeepMerge(base, overlay, opts)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(m)
+
+	opts.Scalars = mapset.ScalarError
+	_, err = mapset.DeepMerge(base, overlay, opts)
+	fmt.Println(err)
+
+	// Output:
+	// map[name:app server:map[hosts:[a b c] port:9090]]
+	// server.port: conflicting values: 8080 ≠ 9090
+}
+
+func TestDeepMergeNoSharing(t *testing.T) {
+	inner := map[string]any{"x": 1}
+	lhs := map[string]any{"a": inner, "s": []any{1}}
+	rhs := map[string]any{"b": inner, "s": []any{2}}
+
+	m, err := mapset.DeepMerge(lhs, rhs, mapset.DeepMergeOptions{Slices: mapset.SliceAppend})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m["a"].(map[string]any)["x"] = 2
+	m["b"].(map[string]any)["x"] = 3
+	m["s"].([]any)[0] = 3
+	if inner["x"] != 1 || lhs["s"].([]any)[0] != 1 {
+		t.Fatal("result must not share maps or slices with the inputs")
+	}
+	if got := len(m["s"].([]any)); got != 2 {
+		t.Fatalf("SliceAppend: got %d elements, want 2", got)
+	}
+}
+
+func ExampleWith() {
+	// a set of counters where a count of 0 is not in the set
+	// and the counts of keys in both sets are summed
+	x := mapset.With[string, int]{
+		M:        map[string]int{"a": 1, "b": 0, "c": 2},
+		Contains: mapset.NonZero[int],
+		Merge:    mapset.Sum[int],
+	}
+
+	fmt.Println("has b?", x.Has("b"))
+	fmt.Println("len:", x.Len())
+
+	y := x.Union(map[string]int{"c": 3, "d": 4})
+	fmt.Println("union:", y.M)
+
+	x.Purge()
+	fmt.Println("purged:", x.M)
+
+	// Output:
+	// has b? false
+	// len: 2
+	// union: map[a:1 c:5 d:4]
+	// purged: map[a:1 c:2]
+}
@@ -0,0 +1,74 @@
+package mapset
+
+// UnionInto adds the items of src to dst.
+// Afterwards, dst is equal to Union(dst, src, contains, merge).
+func UnionInto[K comparable, V any, M ~map[K]V](dst, src M, contains ContainsFunc[V], merge MergeFunc[V]) {
+	Purge(dst, contains)
+	unionInto(dst, src, contains, merge)
+}
+
+// unionInto is UnionInto without the initial Purge of dst,
+// for when dst is already known to be purged.
+func unionInto[K comparable, V any, M ~map[K]V](dst, src M, contains ContainsFunc[V], merge MergeFunc[V]) {
+	for k, v := range src {
+		if contains.Check(v) {
+			if L, ok := dst[k]; !ok {
+				// new key, add
+				dst[k] = v
+			} else {
+				// key exists in both maps, do a merge
+				v := merge.Into(L, v)
+
+				dst[k] = v
+				// make sure two valid entries aren't merged into an invalid entry
+				if !contains.Check(v) {
+					delete(dst, k)
+				}
+			}
+		}
+	}
+}
+
+// IntersectInPlace removes the items of m that are not in other.
+// Afterwards, m is equal to Intersect(m, other, contains, merge).
+func IntersectInPlace[K comparable, V any, M ~map[K]V](m, other M, contains ContainsFunc[V], merge MergeFunc[V]) {
+	for k, v := range m {
+		vp, ok := other[k]
+		if ok && contains.Check(v) && contains.Check(vp) {
+			vf := merge.Into(v, vp)
+			if contains.Check(vf) {
+				m[k] = vf
+				continue
+			}
+		}
+		delete(m, k)
+	}
+}
+
+// DiffInPlace removes the items of m that are in other.
+// Afterwards, m is equal to Diff(m, other, contains).
+func DiffInPlace[K comparable, V any, M ~map[K]V](m, other M, contains ContainsFunc[V]) {
+	for k, v := range m {
+		if !contains.Check(v) || Contains(other, k, contains) {
+			delete(m, k)
+		}
+	}
+}
+
+// SymDiffInPlace removes the items of m that are in other and adds the items of other that are not in m.
+// Afterwards, m is equal to SymDiff(m, other, contains).
+func SymDiffInPlace[K comparable, V any, M ~map[K]V](m, other M, contains ContainsFunc[V]) {
+	for k, v := range other {
+		if !contains.Check(v) {
+			continue
+		}
+		if Contains(m, k, contains) {
+			// k in both
+			delete(m, k)
+		} else {
+			// k in other but not m
+			m[k] = v
+		}
+	}
+	Purge(m, contains)
+}
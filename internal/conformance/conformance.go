@@ -0,0 +1,89 @@
+// Package conformance checks that the set types of mapset and its subpackages
+// agree with the generic functions they are defined by.
+package conformance
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/jimmyfrasche/mapset"
+)
+
+// Set is the method set shared by mapset.Set, mapset.Bool, and multiset.Of.
+type Set[S any, V comparable] interface {
+	~map[string]V
+	Union(S) S
+	Intersect(S) S
+	Diff(S) S
+	SymDiff(S) S
+	Contains(string) bool
+	Equal(S) bool
+	Disjoint(S) bool
+	Len() int
+	Clone() S
+	Keys() []string
+	Values() []V
+	Purge()
+	Filter(func(string) bool) S
+}
+
+// Check compares the methods of every pair of sets against the generic functions of mapset,
+// with union and intersect the merges of Union and Intersect.
+func Check[S Set[S, V], V comparable](t testing.TB, sets []S, contains mapset.ContainsFunc[V], union, intersect mapset.MergeFunc[V]) {
+	t.Helper()
+	for _, x := range sets {
+		for _, y := range sets {
+			check := func(name string, got, want any) {
+				t.Helper()
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("%v.%s(%v): got %v, want %v", x, name, y, got, want)
+				}
+			}
+			check("Union", x.Union(y), mapset.Union(x, y, contains, union))
+			check("Intersect", x.Intersect(y), mapset.Intersect(x, y, contains, intersect))
+			check("Diff", x.Diff(y), mapset.Diff(x, y, contains))
+			check("SymDiff", x.SymDiff(y), mapset.SymDiff(x, y, contains))
+			check("Equal", x.Equal(y), mapset.EqualValues(x, y, contains))
+			check("Disjoint", x.Disjoint(y), mapset.Disjoint(x, y, contains))
+		}
+
+		check := func(name string, got, want any) {
+			t.Helper()
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%v.%s: got %v, want %v", x, name, got, want)
+			}
+		}
+		check("Contains", x.Contains("a"), mapset.Contains(x, "a", contains))
+		check("Len", x.Len(), mapset.Len(x, contains))
+		check("Clone", x.Clone(), mapset.Clone(x, contains))
+		check("Keys", sorted(x.Keys()), sorted(mapset.Keys(x, contains)))
+		check("Values", count(x.Values()), count(mapset.Values(x, contains)))
+
+		notA := func(k string) bool {
+			return k != "a"
+		}
+		want := mapset.Clone(x, contains)
+		delete(want, "a")
+		check("Filter", x.Filter(notA), want)
+
+		p := maps.Clone(x)
+		p.Purge()
+		check("Purge", p, mapset.Clone(x, contains))
+	}
+}
+
+func sorted(s []string) []string {
+	slices.Sort(s)
+	return s
+}
+
+// count returns the number of times each value occurs in vs.
+func count[V comparable](vs []V) map[V]int {
+	out := map[V]int{}
+	for _, v := range vs {
+		out[v]++
+	}
+	return out
+}
@@ -0,0 +1,53 @@
+package jsonpatch_test
+
+import (
+	"fmt"
+
+	"github.com/jimmyfrasche/mapset/jsonpatch"
+)
+
+func eq(x, y int) bool {
+	return x == y
+}
+
+func ExampleDiff() {
+	before := map[string]int{"a": 1, "b/c": 2, "d~e": 3}
+	after := map[string]int{"a": 10, "d~e": 3, "f": 4}
+
+	patch, err := jsonpatch.Diff(before, after, nil, eq)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(string(patch))
+
+	m := map[string]int{"a": 1, "b/c": 2, "d~e": 3}
+	if err := jsonpatch.Apply(m, patch); err != nil {
+		panic(err)
+	}
+	fmt.Println(m)
+
+	// Output:
+	// [{"op":"remove","path":"/b~1c"},{"op":"replace","path":"/a","value":10},{"op":"add","path":"/f","value":4}]
+	// map[a:10 d~e:3 f:4]
+}
+
+func ExampleDiffMerge() {
+	before := map[string]int{"a": 1, "b": 2, "c": 3}
+	after := map[string]int{"a": 10, "c": 3, "d": 4}
+
+	patch, err := jsonpatch.DiffMerge(before, after, nil, eq)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(string(patch))
+
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	if err := jsonpatch.ApplyMerge(m, patch); err != nil {
+		panic(err)
+	}
+	fmt.Println(m)
+
+	// Output:
+	// {"a":10,"b":null,"d":4}
+	// map[a:10 c:3 d:4]
+}
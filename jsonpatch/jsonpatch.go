@@ -0,0 +1,216 @@
+// Package jsonpatch converts the [mapset.Delta] of two maps with string keys
+// to and from RFC 6902 JSON Patch and RFC 7396 JSON Merge Patch documents.
+//
+// The maps are treated as JSON objects so the patches produced only refer to top-level members.
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/jimmyfrasche/mapset"
+)
+
+var (
+	// ErrPath is returned when a JSON Patch refers to a path other than a top-level member.
+	ErrPath = errors.New("unsupported path")
+	// ErrOp is returned when a JSON Patch contains an unknown operation.
+	ErrOp = errors.New("unknown operation")
+	// ErrTest is returned when a JSON Patch test operation fails.
+	ErrTest = errors.New("test failed")
+	// ErrMissing is returned when a JSON Patch refers to a member that does not exist.
+	ErrMissing = errors.New("missing member")
+	// ErrNull is returned when a JSON Merge Patch needs to set a value to null,
+	// which RFC 7396 cannot represent.
+	ErrNull = errors.New("merge patch cannot set null")
+	// ErrNilMap is returned when a patch would add members to a nil map.
+	ErrNilMap = errors.New("patch adds members to nil map")
+)
+
+// Operation is a single operation of a JSON Patch.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Pointer returns the JSON Pointer to the top-level member key.
+func Pointer[K ~string](key K) string {
+	r := strings.NewReplacer("~", "~0", "/", "~1")
+	return "/" + r.Replace(string(key))
+}
+
+// member returns the key of the top-level member referred to by the JSON Pointer p.
+func member(p string) (string, error) {
+	if !strings.HasPrefix(p, "/") || strings.Contains(p[1:], "/") {
+		return "", fmt.Errorf("%w: %q", ErrPath, p)
+	}
+	r := strings.NewReplacer("~1", "/", "~0", "~")
+	return r.Replace(p[1:]), nil
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys[K ~string, V any](m map[K]V) []K {
+	return slices.Sorted(maps.Keys(m))
+}
+
+// Operations returns the JSON Patch operations that apply d.
+//
+// All the removals are first, then the replacements, then the additions,
+// each in order of their keys.
+func Operations[K ~string, V any](d mapset.Delta[K, V]) ([]Operation, error) {
+	ops := make([]Operation, 0, len(d.Removed)+len(d.Changed)+len(d.Added))
+	for _, k := range sortedKeys(d.Removed) {
+		ops = append(ops, Operation{Op: "remove", Path: Pointer(k)})
+	}
+	for _, k := range sortedKeys(d.Changed) {
+		v, err := json.Marshal(d.Changed[k].New)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, Operation{Op: "replace", Path: Pointer(k), Value: v})
+	}
+	for _, k := range sortedKeys(d.Added) {
+		v, err := json.Marshal(d.Added[k])
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, Operation{Op: "add", Path: Pointer(k), Value: v})
+	}
+	return ops, nil
+}
+
+// Encode returns the RFC 6902 JSON Patch document that applies d.
+//
+// See [Operations] for the order of the operations.
+func Encode[K ~string, V any](d mapset.Delta[K, V]) ([]byte, error) {
+	ops, err := Operations(d)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(ops)
+}
+
+// Diff returns the RFC 6902 JSON Patch document that turns before into after.
+// It is Encode(mapset.DiffReport(before, after, contains, eq)).
+// A nil eq treats all values as unequal, so every key in both maps is replaced.
+func Diff[K ~string, V any, M ~map[K]V](before, after M, contains mapset.ContainsFunc[V], eq func(V, V) bool) ([]byte, error) {
+	return Encode(mapset.DiffReport(before, after, contains, eq))
+}
+
+// Apply applies the RFC 6902 JSON Patch document patch to m.
+//
+// Only paths that refer to top-level members are supported.
+// If any operation fails, m is left unchanged.
+// A nil m can only be patched if it stays empty; otherwise [ErrNilMap] is returned.
+func Apply[K ~string, V any, M ~map[K]V](m M, patch []byte) error {
+	var ops []Operation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return err
+	}
+
+	out := make(M, len(m))
+	maps.Copy(out, m)
+	for i, op := range ops {
+		if err := apply(out, op); err != nil {
+			return fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+	return replace(m, out)
+}
+
+// replace sets the contents of m to those of out,
+// failing with ErrNilMap if m is nil and out is not empty.
+func replace[K comparable, V any, M ~map[K]V](m, out M) error {
+	if m == nil && len(out) > 0 {
+		return ErrNilMap
+	}
+	clear(m)
+	maps.Copy(m, out)
+	return nil
+}
+
+func apply[K ~string, V any, M ~map[K]V](m M, op Operation) error {
+	s, err := member(op.Path)
+	if err != nil {
+		return err
+	}
+	k := K(s)
+
+	switch op.Op {
+	case "add", "replace":
+		if _, ok := m[k]; op.Op == "replace" && !ok {
+			return fmt.Errorf("%w: %q", ErrMissing, op.Path)
+		}
+		var v V
+		if err := json.Unmarshal(op.Value, &v); err != nil {
+			return err
+		}
+		m[k] = v
+
+	case "remove":
+		if _, ok := m[k]; !ok {
+			return fmt.Errorf("%w: %q", ErrMissing, op.Path)
+		}
+		delete(m, k)
+
+	case "move", "copy":
+		f, err := member(op.From)
+		if err != nil {
+			return err
+		}
+		v, ok := m[K(f)]
+		if !ok {
+			return fmt.Errorf("%w: %q", ErrMissing, op.From)
+		}
+		if op.Op == "move" {
+			delete(m, K(f))
+		}
+		m[k] = v
+
+	case "test":
+		v, ok := m[k]
+		if !ok {
+			return fmt.Errorf("%w: %q", ErrMissing, op.Path)
+		}
+		if eq, err := jsonEqual(v, op.Value); err != nil {
+			return err
+		} else if !eq {
+			return fmt.Errorf("%w: %q", ErrTest, op.Path)
+		}
+
+	default:
+		return fmt.Errorf("%w: %q", ErrOp, op.Op)
+	}
+	return nil
+}
+
+// jsonEqual reports whether v and the JSON document raw are the same JSON value.
+func jsonEqual[V any](v V, raw json.RawMessage) (bool, error) {
+	x, err := toJSON(v)
+	if err != nil {
+		return false, err
+	}
+	var y any
+	if err := json.Unmarshal(raw, &y); err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(x, y), nil
+}
+
+// toJSON converts v to the generic representation of its JSON encoding.
+func toJSON(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var x any
+	err = json.Unmarshal(b, &x)
+	return x, err
+}
@@ -0,0 +1,115 @@
+package jsonpatch
+
+import (
+	"errors"
+	"maps"
+	"reflect"
+	"testing"
+)
+
+func TestApplyAtomic(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	patch := []byte(`[
+		{"op": "remove", "path": "/a"},
+		{"op": "test", "path": "/b", "value": 3}
+	]`)
+
+	err := Apply(m, patch)
+	if !errors.Is(err, ErrTest) {
+		t.Fatalf("got %v, want ErrTest", err)
+	}
+	if !maps.Equal(m, map[string]int{"a": 1, "b": 2}) {
+		t.Fatalf("m modified by failed patch: %v", m)
+	}
+
+	patch = []byte(`[
+		{"op": "move", "from": "/a", "path": "/c"},
+		{"op": "copy", "from": "/b", "path": "/d"},
+		{"op": "test", "path": "/c", "value": 1}
+	]`)
+	if err := Apply(m, patch); err != nil {
+		t.Fatal(err)
+	}
+	if !maps.Equal(m, map[string]int{"b": 2, "c": 1, "d": 2}) {
+		t.Fatalf("got %v", m)
+	}
+
+	if err := Apply(m, []byte(`[{"op": "add", "path": "/x/y", "value": 1}]`)); !errors.Is(err, ErrPath) {
+		t.Fatalf("got %v, want ErrPath", err)
+	}
+}
+
+func TestNilMap(t *testing.T) {
+	var m map[string]int
+	if err := Apply(m, []byte(`[{"op": "add", "path": "/a", "value": 1}]`)); !errors.Is(err, ErrNilMap) {
+		t.Fatalf("Apply: got %v, want ErrNilMap", err)
+	}
+	if err := ApplyMerge(m, []byte(`{"a": 1}`)); !errors.Is(err, ErrNilMap) {
+		t.Fatalf("ApplyMerge: got %v, want ErrNilMap", err)
+	}
+
+	// patches that leave m empty are fine
+	if err := Apply(m, []byte(`[]`)); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if err := ApplyMerge(m, []byte(`{"a": null}`)); err != nil {
+		t.Fatalf("ApplyMerge: %v", err)
+	}
+}
+
+func TestMergeNested(t *testing.T) {
+	type obj = map[string]any
+
+	before := map[string]obj{"a": {"x": 1.0, "y": obj{"p": 1.0, "q": 2.0}}}
+	after := map[string]obj{"a": {"y": obj{"p": 1.0, "q": 3.0}, "z": true}}
+
+	eq := func(x, y obj) bool {
+		return reflect.DeepEqual(x, y)
+	}
+
+	patch, err := DiffMerge(before, after, nil, eq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"a":{"x":null,"y":{"q":3},"z":true}}`; string(patch) != want {
+		t.Fatalf("got %s, want %s", patch, want)
+	}
+
+	if err := ApplyMerge(before, patch); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(before, after) {
+		t.Fatalf("got %v, want %v", before, after)
+	}
+
+	after["b"] = obj{"n": nil}
+	if _, err := DiffMerge(map[string]obj{}, after, nil, eq); !errors.Is(err, ErrNull) {
+		t.Fatalf("got %v, want ErrNull", err)
+	}
+}
+
+func TestDiffNilEq(t *testing.T) {
+	before := map[string]int{"a": 1, "b": 2}
+	after := map[string]int{"a": 10, "b": 2}
+
+	// a nil eq treats all values as unequal, so every key in both maps is replaced
+	patch, err := Diff(before, after, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := maps.Clone(before)
+	if err := Apply(m, patch); err != nil {
+		t.Fatal(err)
+	}
+	if !maps.Equal(m, after) {
+		t.Fatalf("Apply(Diff) = %v, want %v", m, after)
+	}
+
+	patch, err = DiffMerge(before, after, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"a":10,"b":2}`; string(patch) != want {
+		t.Fatalf("DiffMerge = %s, want %s", patch, want)
+	}
+}
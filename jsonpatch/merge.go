@@ -0,0 +1,178 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+
+	"github.com/jimmyfrasche/mapset"
+)
+
+// EncodeMerge returns the RFC 7396 JSON Merge Patch document that applies d.
+//
+// Removed keys are set to null.
+// When the old and new values of a changed key are both JSON objects,
+// the member is a merge patch of those objects
+// so that it has the correct result when applied recursively.
+//
+// EncodeMerge returns [ErrNull] if an added or changed value,
+// or any member of an object within it, encodes to null
+// as a JSON Merge Patch has no way to set a value to null.
+func EncodeMerge[K ~string, V any](d mapset.Delta[K, V]) ([]byte, error) {
+	patch := make(map[string]any, len(d.Removed)+len(d.Changed)+len(d.Added))
+	for k := range d.Removed {
+		patch[string(k)] = nil
+	}
+	for k, c := range d.Changed {
+		from, err := toJSON(c.Old)
+		if err != nil {
+			return nil, err
+		}
+		to, err := toJSON(c.New)
+		if err != nil {
+			return nil, err
+		}
+		v, err := mergeDiff(from, to)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", k, err)
+		}
+		patch[string(k)] = v
+	}
+	for k, v := range d.Added {
+		x, err := toJSON(v)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkNull(x); err != nil {
+			return nil, fmt.Errorf("key %q: %w", k, err)
+		}
+		patch[string(k)] = x
+	}
+	return json.Marshal(patch)
+}
+
+// DiffMerge returns the RFC 7396 JSON Merge Patch document that turns before into after.
+// It is EncodeMerge(mapset.DiffReport(before, after, contains, eq)).
+// A nil eq treats all values as unequal, so every key in both maps is in the patch.
+func DiffMerge[K ~string, V any, M ~map[K]V](before, after M, contains mapset.ContainsFunc[V], eq func(V, V) bool) ([]byte, error) {
+	return EncodeMerge(mapset.DiffReport(before, after, contains, eq))
+}
+
+// checkNull returns ErrNull if x is or contains a null.
+func checkNull(x any) error {
+	switch x := x.(type) {
+	case nil:
+		return ErrNull
+	case map[string]any:
+		for _, v := range x {
+			if err := checkNull(v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// mergeDiff returns the merge patch that turns from into to.
+func mergeDiff(from, to any) (any, error) {
+	o, ook := from.(map[string]any)
+	n, nok := to.(map[string]any)
+	if !ook || !nok {
+		// not both objects so replace the value entirely
+		return to, checkNull(to)
+	}
+	patch := map[string]any{}
+	for k := range o {
+		if _, ok := n[k]; !ok {
+			patch[k] = nil
+		}
+	}
+	for k, nv := range n {
+		if ov, ok := o[k]; ok && reflect.DeepEqual(ov, nv) {
+			// nothing changed
+			continue
+		}
+		// a missing old value is nil, which is never an object
+		v, err := mergeDiff(o[k], nv)
+		if err != nil {
+			return nil, err
+		}
+		patch[k] = v
+	}
+	return patch, nil
+}
+
+// mustRaw encodes a value that came from decoding JSON.
+func mustRaw(x any) json.RawMessage {
+	b, err := json.Marshal(x)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// mergePatch applies the merge patch to target as described in RFC 7396.
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = map[string]any{}
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+		} else {
+			t[k] = mergePatch(t[k], v)
+		}
+	}
+	return t
+}
+
+// ApplyMerge applies the RFC 7396 JSON Merge Patch document patch to m.
+//
+// Members of the patch that are objects are merged into the existing values,
+// which are converted to and from JSON to do so.
+// If the patch is not an object or cannot be applied, m is left unchanged.
+// A nil m can only be patched if it stays empty; otherwise [ErrNilMap] is returned.
+func ApplyMerge[K ~string, V any, M ~map[K]V](m M, patch []byte) error {
+	var p map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return err
+	}
+
+	out := make(M, len(m))
+	maps.Copy(out, m)
+	for s, raw := range p {
+		k := K(s)
+		var x any
+		if err := json.Unmarshal(raw, &x); err != nil {
+			return err
+		}
+		if x == nil {
+			delete(out, k)
+			continue
+		}
+		if old, ok := out[k]; ok {
+			if _, isObj := x.(map[string]any); isObj {
+				t, err := toJSON(old)
+				if err != nil {
+					return err
+				}
+				raw = mustRaw(mergePatch(t, x))
+			}
+		} else {
+			// a new member must still have its nulls removed
+			raw = mustRaw(mergePatch(nil, x))
+		}
+		var v V
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return fmt.Errorf("key %q: %w", s, err)
+		}
+		out[k] = v
+	}
+	return replace(m, out)
+}
@@ -0,0 +1,77 @@
+package mapset
+
+// The functions in this file only consider the keys of two maps,
+// so the maps may have different value types.
+// Each map has its own [ContainsFunc].
+//
+// A [Set] can be used with a nil ContainsFunc.
+// A [Bool] or multiset.Of can be used with [NonZero].
+
+// Restrict creates a new M with the items of m whose keys are in filter.
+// Items of m must pass mc and keys of filter must pass fc.
+func Restrict[K comparable, V, W any, M ~map[K]V, F ~map[K]W](m M, mc ContainsFunc[V], filter F, fc ContainsFunc[W]) M {
+	out := make(M, min(len(m), len(filter)))
+	for k, v := range m {
+		if mc.Check(v) && Contains(filter, k, fc) {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// Without creates a new M with the items of m whose keys are not in filter.
+// Items of m must pass mc and keys of filter must pass fc.
+func Without[K comparable, V, W any, M ~map[K]V, F ~map[K]W](m M, mc ContainsFunc[V], filter F, fc ContainsFunc[W]) M {
+	out := make(M, len(m))
+	for k, v := range m {
+		if mc.Check(v) && !Contains(filter, k, fc) {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// DisjointKeys is [Disjoint] for maps with different value types.
+func DisjointKeys[K comparable, V, W any, M ~map[K]V, N ~map[K]W](lhs M, lc ContainsFunc[V], rhs N, rc ContainsFunc[W]) bool {
+	if len(rhs) < len(lhs) {
+		for k, v := range rhs {
+			if rc.Check(v) && Contains(lhs, k, lc) {
+				return false
+			}
+		}
+		return true
+	}
+	for k, v := range lhs {
+		if lc.Check(v) && Contains(rhs, k, rc) {
+			return false
+		}
+	}
+	return true
+}
+
+// subsetKeys reports whether lhs is a subset of rhs and, if so, the number of items in lhs.
+func subsetKeys[K comparable, V, W any, M ~map[K]V, N ~map[K]W](lhs M, lc ContainsFunc[V], rhs N, rc ContainsFunc[W]) (int, bool) {
+	n := 0
+	for k, v := range lhs {
+		if !lc.Check(v) {
+			continue
+		}
+		if !Contains(rhs, k, rc) {
+			return 0, false
+		}
+		n++
+	}
+	return n, true
+}
+
+// SubsetKeys is [Subset] for maps with different value types.
+func SubsetKeys[K comparable, V, W any, M ~map[K]V, N ~map[K]W](lhs M, lc ContainsFunc[V], rhs N, rc ContainsFunc[W]) bool {
+	_, ok := subsetKeys(lhs, lc, rhs, rc)
+	return ok
+}
+
+// EqualKeys is [Equal] for maps with different value types.
+func EqualKeys[K comparable, V, W any, M ~map[K]V, N ~map[K]W](lhs M, lc ContainsFunc[V], rhs N, rc ContainsFunc[W]) bool {
+	n, ok := subsetKeys(lhs, lc, rhs, rc)
+	return ok && n == Len(rhs, rc)
+}
@@ -0,0 +1,104 @@
+package mapset
+
+import "iter"
+
+// Merge3 performs a three-way merge of ours and theirs, two maps derived from base.
+//
+// The changes made by each side are computed with [DiffReport]
+// so only items whose values pass the [ContainsFunc] check are considered
+// and eq decides whether two values are the same.
+// Merge3 panics if eq is nil, as without it a key that was kept cannot be told apart from an edit.
+//
+// For each key, a change made by only one side, or the same change made by both sides, is taken.
+// A change is an addition, a removal, or an edit.
+//
+// All other keys are conflicts and are reported in no particular order:
+//   - If both sides have a value, resolve merges ours and theirs into the value in the result.
+//     As with any [MergeFunc], a nil resolve keeps ours.
+//   - If one side removed the key, the edited value of the other side is kept.
+//
+// As with [Union], a resolved value that fails the [ContainsFunc] check is left out of the result.
+func Merge3[K comparable, V any, M ~map[K]V](base, ours, theirs M, contains ContainsFunc[V], eq func(V, V) bool, resolve MergeFunc[V]) (M, []K) {
+	if eq == nil {
+		panic("mapset: Merge3 called with a nil eq")
+	}
+	dOurs := DiffReport(base, ours, contains, eq)
+	dTheirs := DiffReport(base, theirs, contains, eq)
+
+	out := Clone(base, contains)
+	var conflicts []K
+	merge := func(k K) {
+		o, ook, oc := lookup(dOurs, k)
+		t, tok, tc := lookup(dTheirs, k)
+		v, ok := o, ook
+		switch {
+		case !tc:
+			// only ours changed
+		case !oc:
+			// only theirs changed
+			v, ok = t, tok
+		case ook == tok && (!ook || eq(o, t)):
+			// both made the same change
+		default:
+			conflicts = append(conflicts, k)
+			switch {
+			case ook && tok:
+				v = resolve.Into(o, t)
+				ok = contains.Check(v)
+			case tok:
+				v, ok = t, true
+			}
+		}
+		if ok {
+			out[k] = v
+		} else {
+			delete(out, k)
+		}
+	}
+
+	for k := range changedKeys(dOurs) {
+		merge(k)
+	}
+	for k := range changedKeys(dTheirs) {
+		if _, _, oc := lookup(dOurs, k); !oc {
+			merge(k)
+		}
+	}
+	return out, conflicts
+}
+
+// lookup reports the value of k after applying d, whether k is present afterwards,
+// and whether d changes k at all.
+func lookup[K comparable, V any](d Delta[K, V], k K) (v V, ok, changed bool) {
+	if v, ok := d.Added[k]; ok {
+		return v, true, true
+	}
+	if _, ok := d.Removed[k]; ok {
+		return v, false, true
+	}
+	if c, ok := d.Changed[k]; ok {
+		return c.New, true, true
+	}
+	return v, false, false
+}
+
+// changedKeys yields each key in d.
+func changedKeys[K comparable, V any](d Delta[K, V]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range d.Added {
+			if !yield(k) {
+				return
+			}
+		}
+		for k := range d.Removed {
+			if !yield(k) {
+				return
+			}
+		}
+		for k := range d.Changed {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package mapset
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Left returns lhs.
+// It is equivalent to a nil [MergeFunc].
+//
+// Left is associative and idempotent but not commutative.
+func Left[V any](lhs, rhs V) V {
+	return lhs
+}
+
+// Right returns rhs.
+//
+// Right is associative and idempotent but not commutative.
+func Right[V any](lhs, rhs V) V {
+	return rhs
+}
+
+// Sum returns lhs + rhs.
+//
+// Sum is commutative and associative for integers.
+// It is not idempotent.
+// For floating point numbers, Sum is commutative but only approximately associative.
+// For strings, Sum is concatenation which is associative but not commutative.
+func Sum[V cmp.Ordered](lhs, rhs V) V {
+	return lhs + rhs
+}
+
+// Min returns the lesser of lhs and rhs.
+//
+// Min is commutative, associative, and idempotent,
+// except for floating point NaN, which is returned if either argument is NaN.
+func Min[V cmp.Ordered](lhs, rhs V) V {
+	return min(lhs, rhs)
+}
+
+// Max returns the greater of lhs and rhs.
+//
+// Max is commutative, associative, and idempotent,
+// except for floating point NaN, which is returned if either argument is NaN.
+func Max[V cmp.Ordered](lhs, rhs V) V {
+	return max(lhs, rhs)
+}
+
+// Either returns lhs || rhs.
+// It is the [MergeFunc] used by [Bool].
+//
+// Either is commutative, associative, and idempotent.
+func Either(lhs, rhs bool) bool {
+	return lhs || rhs
+}
+
+// Both returns lhs && rhs.
+//
+// Both is commutative, associative, and idempotent.
+func Both(lhs, rhs bool) bool {
+	return lhs && rhs
+}
+
+// AppendSlices returns a new slice of the elements of lhs followed by the elements of rhs.
+// Neither input is modified.
+//
+// AppendSlices is associative but not commutative or idempotent.
+func AppendSlices[S ~[]E, E any](lhs, rhs S) S {
+	return slices.Concat(lhs, rhs)
+}
+
+// AppendUnique returns a new slice of the elements of lhs followed by the elements of rhs
+// that are not in lhs, keeping only the first occurrence of each of those.
+// The elements of lhs are kept as they are, even if repeated.
+// Neither input is modified.
+//
+// AppendUnique is associative and idempotent but not commutative.
+func AppendUnique[S ~[]E, E comparable](lhs, rhs S) S {
+	seen := make(map[E]struct{}, len(lhs)+len(rhs))
+	for _, e := range lhs {
+		seen[e] = struct{}{}
+	}
+	out := slices.Grow(slices.Clone(lhs), len(rhs))
+	for _, e := range rhs {
+		if _, ok := seen[e]; !ok {
+			seen[e] = struct{}{}
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// MergeMaps returns a [MergeFunc] that merges two maps with Union(lhs, rhs, nil, merge).
+// Nested maps can be merged recursively by passing the result of another MergeMaps as merge.
+//
+// The result has the same algebraic properties as merge.
+func MergeMaps[M ~map[K]V, K comparable, V any](merge MergeFunc[V]) MergeFunc[M] {
+	return func(lhs, rhs M) M {
+		return Union(lhs, rhs, nil, merge)
+	}
+}
+
+// FirstNonZero returns lhs unless it is the zero value of V, in which case it returns rhs.
+//
+// FirstNonZero is associative and idempotent but not commutative.
+func FirstNonZero[V comparable](lhs, rhs V) V {
+	var zero V
+	if lhs != zero {
+		return lhs
+	}
+	return rhs
+}
+
+// Chain returns a [MergeFunc] that tries each of ms in order
+// and returns the first result that is not the zero value of V.
+// If every result is zero, it returns the zero value.
+// A nil entry in ms returns lhs, like any nil MergeFunc.
+//
+// The properties of Chain depend on ms.
+// It is commutative if every entry of ms is commutative
+// and idempotent if every entry of ms is idempotent,
+// but it is not associative in general, even when every entry of ms is.
+func Chain[V comparable](ms ...MergeFunc[V]) MergeFunc[V] {
+	return func(lhs, rhs V) V {
+		var zero V
+		for _, m := range ms {
+			if v := m.Into(lhs, rhs); v != zero {
+				return v
+			}
+		}
+		return zero
+	}
+}
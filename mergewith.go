@@ -0,0 +1,90 @@
+package mapset
+
+import "iter"
+
+// MergeWithFunc decides the value of key in the result of a [MergeWith].
+// l and lok are the result of lhs[key] and r and rok are the result of rhs[key].
+// At least one of lok and rok is true.
+//
+// The key is included in the result with the returned value if the returned bool is true.
+type MergeWithFunc[K comparable, V any] func(key K, l V, lok bool, r V, rok bool) (V, bool)
+
+// MergeWith creates a new M by calling f once for each key in either lhs or rhs.
+//
+// This is a full outer merge that the other operations are special cases of.
+// [Union] and [SymDiff] are implemented with MergeWith.
+// [Intersect] and [Diff] are equivalent to MergeWith calls that only keep keys in lhs,
+// but are implemented without visiting the keys only in rhs.
+func MergeWith[K comparable, V any, M ~map[K]V](lhs, rhs M, f MergeWithFunc[K, V]) M {
+	var zero V
+	out := make(M, max(len(lhs), len(rhs)))
+	for k, l := range lhs {
+		r, rok := rhs[k]
+		if v, ok := f(k, l, true, r, rok); ok {
+			out[k] = v
+		}
+	}
+	for k, r := range rhs {
+		if _, lok := lhs[k]; lok {
+			// handled above
+			continue
+		}
+		if v, ok := f(k, zero, false, r, true); ok {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// MergeWithSeq returns an iterator over the result of MergeWith(lhs, rhs, f) without allocating a result map.
+func MergeWithSeq[K comparable, V any, M ~map[K]V](lhs, rhs M, f MergeWithFunc[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var zero V
+		for k, l := range lhs {
+			r, rok := rhs[k]
+			if v, ok := f(k, l, true, r, rok); ok && !yield(k, v) {
+				return
+			}
+		}
+		for k, r := range rhs {
+			if _, lok := lhs[k]; lok {
+				// handled above
+				continue
+			}
+			if v, ok := f(k, zero, false, r, true); ok && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// MergeWithInPlace sets m to the result of MergeWith(m, other, f).
+func MergeWithInPlace[K comparable, V any, M ~map[K]V](m, other M, f MergeWithFunc[K, V]) {
+	type entry struct {
+		k K
+		v V
+	}
+	// keys only in other must be added after iterating m
+	// so that they are not mistaken for keys of m.
+	var zero V
+	var pending []entry
+	for k, r := range other {
+		if _, lok := m[k]; lok {
+			continue
+		}
+		if v, ok := f(k, zero, false, r, true); ok {
+			pending = append(pending, entry{k, v})
+		}
+	}
+	for k, l := range m {
+		r, rok := other[k]
+		if v, ok := f(k, l, true, r, rok); ok {
+			m[k] = v
+		} else {
+			delete(m, k)
+		}
+	}
+	for _, e := range pending {
+		m[e.k] = e.v
+	}
+}
@@ -2,7 +2,10 @@ package multiset_test
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 
+	"github.com/jimmyfrasche/mapset"
 	"github.com/jimmyfrasche/mapset/multiset"
 )
 
@@ -23,7 +26,7 @@ func ExampleOf_Intersect() {
 	fmt.Println(x.Intersect(y))
 
 	// Output:
-	// map[a:1 c:3 d:4 e:1]
+	// map[c:3 e:1]
 }
 
 func ExampleOf_Add() {
@@ -42,8 +45,8 @@ func ExampleOf_Sub() {
 	fmt.Println(x.Sub(y))
 	fmt.Println(y.Sub(x))
 	// Output:
-	// map[a:1 c:2 d:4]
-	// map[a:1 d:4]
+	// map[a:1 c:2]
+	// map[d:4]
 }
 
 func ExampleOf_Included() {
@@ -100,3 +103,80 @@ func ExampleOf_Cardinality() {
 	// Output:
 	// 6
 }
+
+func ExampleOf_All() {
+	x := multiset.Of[string]{}
+	x.ExtendSeq(slices.Values([]string{"a", "b", "a", "c", "a"}))
+	x["d"] = 0
+
+	fmt.Println(maps.Collect(x.All()))
+
+	// Output:
+	// map[a:3 b:1 c:1]
+}
+
+func ExampleOf_AddWith() {
+	x := multiset.Of[string]{"a": 1, "b": 0, "c": 5}
+	y := multiset.Of[string]{"c": 3, "d": 4}
+
+	x.AddWith(y)
+	fmt.Println(x)
+
+	// Output:
+	// map[a:1 c:8 d:4]
+}
+
+func ExampleOf_filter() {
+	stock := map[string]string{"apple": "aisle 1", "pear": "aisle 2", "fig": "aisle 3"}
+	cart := multiset.Of[string]{"apple": 3, "fig": 0}
+
+	fmt.Println(mapset.Restrict(stock, nil, cart, mapset.NonZero[uint64]))
+
+	// Output:
+	// map[apple:aisle 1]
+}
+
+func ExampleResize() {
+	x := multiset.Of[string]{"a": 1, "b": 200, "c": 0}
+
+	y, err := multiset.Resize[uint8](x)
+	fmt.Println(y, err)
+
+	y.Inc("a", 9)
+	y = y.Add(multiset.OfN[string, uint8]{"b": 50})
+	fmt.Println(y)
+
+	x["d"] = 300
+	_, err = multiset.Resize[uint8](x)
+	fmt.Println(err)
+
+	// Output:
+	// map[a:1 b:200] <nil>
+	// map[a:10 b:250]
+	// d: 300: multiplicity truncated
+}
+
+func ExampleZ() {
+	// the contents of a view and a change to it
+	view := multiset.FromOf(multiset.Of[string]{"a": 2, "b": 1})
+	delta := multiset.Z[string]{"a": -2, "b": 1, "c": 1}
+
+	next := view.Add(delta)
+	fmt.Println("next:", next)
+
+	// subtracting the delta recovers the original view
+	fmt.Println("undo:", next.Sub(delta))
+
+	fmt.Println("negated:", delta.Negate())
+	fmt.Println("positive:", delta.Positive())
+	fmt.Println("distinct:", next.Distinct())
+	fmt.Println("as multiset:", next.ToOf())
+
+	// Output:
+	// next: map[b:2 c:1]
+	// undo: map[a:2 b:1]
+	// negated: map[a:2 b:-1 c:-1]
+	// positive: map[b:1 c:1]
+	// distinct: map[b:1 c:1]
+	// as multiset: map[b:2 c:1]
+}
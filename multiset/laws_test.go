@@ -0,0 +1,186 @@
+package multiset
+
+import (
+	"math"
+	"testing"
+	"testing/quick"
+)
+
+// small converts a map generated by testing/quick into a multiset
+// with few distinct keys, so that the operands overlap,
+// and small multiplicities, so that sums cannot overflow.
+func small(m map[uint8]uint8) Of[uint8] {
+	out := Of[uint8]{}
+	for k, v := range m {
+		out[k%8] = uint64(v % 16)
+	}
+	return out
+}
+
+func law(t *testing.T, name string, f any) {
+	t.Helper()
+	if err := quick.Check(f, nil); err != nil {
+		t.Errorf("%s: %v", name, err)
+	}
+}
+
+func TestLaws(t *testing.T) {
+	type gen = map[uint8]uint8
+
+	law(t, "union commutes", func(a, b gen) bool {
+		x, y := small(a), small(b)
+		return x.Union(y).Equal(y.Union(x))
+	})
+	law(t, "intersect commutes", func(a, b gen) bool {
+		x, y := small(a), small(b)
+		return x.Intersect(y).Equal(y.Intersect(x))
+	})
+	law(t, "add commutes", func(a, b gen) bool {
+		x, y := small(a), small(b)
+		return x.Add(y).Equal(y.Add(x))
+	})
+	law(t, "union, intersect, and add associate", func(a, b, c gen) bool {
+		x, y, z := small(a), small(b), small(c)
+		return x.Union(y).Union(z).Equal(x.Union(y.Union(z))) &&
+			x.Intersect(y).Intersect(z).Equal(x.Intersect(y.Intersect(z))) &&
+			x.Add(y).Add(z).Equal(x.Add(y.Add(z)))
+	})
+	law(t, "union and intersect are idempotent", func(a gen) bool {
+		x := small(a)
+		return x.Union(x).Equal(x) && x.Intersect(x).Equal(x)
+	})
+	law(t, "absorption", func(a, b gen) bool {
+		x, y := small(a), small(b)
+		return x.Union(x.Intersect(y)).Equal(x) && x.Intersect(x.Union(y)).Equal(x)
+	})
+	law(t, "intersect distributes over union", func(a, b, c gen) bool {
+		x, y, z := small(a), small(b), small(c)
+		return x.Intersect(y.Union(z)).Equal(x.Intersect(y).Union(x.Intersect(z)))
+	})
+	law(t, "intersect ≤ operands ≤ union", func(a, b gen) bool {
+		x, y := small(a), small(b)
+		i, u := x.Intersect(y), x.Union(y)
+		return i.Included(x) && i.Included(y) && x.Included(u) && y.Included(u)
+	})
+	law(t, "intersect has only common keys", func(a, b gen) bool {
+		x, y := small(a), small(b)
+		for k := range x.Intersect(y) {
+			if !x.Contains(k) || !y.Contains(k) {
+				return false
+			}
+		}
+		return true
+	})
+	law(t, "sub undoes add", func(a, b gen) bool {
+		x, y := small(a), small(b)
+		return x.Add(y).Sub(y).Equal(x)
+	})
+	law(t, "sub ≤ lhs", func(a, b gen) bool {
+		x, y := small(a), small(b)
+		return x.Sub(y).Included(x)
+	})
+	law(t, "sub then add covers lhs", func(a, b gen) bool {
+		x, y := small(a), small(b)
+		return x.Included(x.Sub(y).Add(y))
+	})
+	law(t, "cardinality", func(a, b gen) bool {
+		x, y := small(a), small(b)
+		return x.Add(y).Cardinality() == x.Cardinality()+y.Cardinality() &&
+			x.Union(y).Cardinality()+x.Intersect(y).Cardinality() == x.Cardinality()+y.Cardinality()
+	})
+	law(t, "proper inclusion", func(a, b gen) bool {
+		x, y := small(a), small(b)
+		return x.ProperIncluded(y) == (x.Included(y) && !x.Equal(y)) && !x.ProperIncluded(x)
+	})
+	law(t, "in-place forms", func(a, b gen) bool {
+		x, y := small(a), small(b)
+		u, i, s, d := x.Clone(), x.Clone(), x.Clone(), x.Clone()
+		u.UnionWith(y)
+		i.IntersectWith(y)
+		s.SubWith(y)
+		d.AddWith(y)
+		return u.Equal(x.Union(y)) && i.Equal(x.Intersect(y)) && s.Equal(x.Sub(y)) && d.Equal(x.Add(y)) &&
+			x.IntersectAll(y).Equal(x.Intersect(y)) && x.DiffAll(y).Equal(x.Diff(y))
+	})
+}
+
+func TestOfNMatchesOf(t *testing.T) {
+	law(t, "OfN[uint8] matches Of", func(a, b map[uint8]uint8) bool {
+		x, y := small(a), small(b)
+		xn, err := Resize[uint8](x)
+		if err != nil {
+			return false
+		}
+		yn, err := Resize[uint8](y)
+		if err != nil {
+			return false
+		}
+		same := func(m Of[uint8], n OfN[uint8, uint8]) bool {
+			r, err := Resize[uint64](n)
+			return err == nil && m.Equal(Of[uint8](r))
+		}
+		return same(x.Union(y), xn.Union(yn)) &&
+			same(x.Intersect(y), xn.Intersect(yn)) &&
+			same(x.Add(y), xn.Add(yn)) &&
+			same(x.Sub(y), xn.Sub(yn)) &&
+			x.Included(y) == xn.Included(yn) &&
+			x.Cardinality() == xn.Cardinality()
+	})
+}
+
+func smallZ(m map[uint8]int8) Z[uint8] {
+	out := Z[uint8]{}
+	for k, v := range m {
+		if v != 0 {
+			out[k%8] = int64(v)
+		}
+	}
+	return out
+}
+
+func TestZLaws(t *testing.T) {
+	type gen = map[uint8]int8
+
+	law(t, "add is an abelian group", func(a, b, c gen) bool {
+		x, y, z := smallZ(a), smallZ(b), smallZ(c)
+		return x.Add(y).Equal(y.Add(x)) &&
+			x.Add(y).Add(z).Equal(x.Add(y.Add(z))) &&
+			x.Add(Z[uint8]{}).Equal(x) &&
+			len(x.Add(x.Negate())) == 0
+	})
+	law(t, "sub adds the negation", func(a, b gen) bool {
+		x, y := smallZ(a), smallZ(b)
+		return x.Sub(y).Equal(x.Add(y.Negate())) && x.Sub(y).Add(y).Equal(x)
+	})
+	law(t, "no zero weights", func(a, b gen) bool {
+		x, y := smallZ(a), smallZ(b)
+		for _, r := range []Z[uint8]{x.Add(y), x.Sub(y), x.Negate(), x.Positive(), x.Distinct()} {
+			for _, v := range r {
+				if v == 0 {
+					return false
+				}
+			}
+		}
+		return true
+	})
+	law(t, "positive and distinct", func(a gen) bool {
+		x := smallZ(a)
+		p, d := x.Positive(), x.Distinct()
+		return p.Distinct().Equal(d) && d.Distinct().Equal(d) &&
+			FromOf(p.ToOf()).Equal(p)
+	})
+}
+
+func TestZOverflow(t *testing.T) {
+	// -1 - MinInt64 fits even though -MinInt64 does not
+	if got := (Z[string]{"a": -1}).Sub(Z[string]{"a": math.MinInt64}); got["a"] != math.MaxInt64 {
+		t.Fatalf("got %v, want a:%d", got, int64(math.MaxInt64))
+	}
+
+	defer func() {
+		if x := recover(); x != ErrOverflow {
+			t.Fatalf("got %v, want panic(ErrOverflow)", x)
+		}
+	}()
+	Z[int]{0: math.MinInt64}.Negate()
+}
@@ -1,27 +1,16 @@
 // Package multiset uses the primitives in mapset to implement a simple multiset.
+//
+// [OfN] is a multiset with narrower multiplicities and [Z] is a signed multiset.
 package multiset
 
 import (
 	"errors"
+	"iter"
 	"math/bits"
 
 	"github.com/jimmyfrasche/mapset"
 )
 
-func min(a, b uint64) uint64 {
-	if a < b {
-		return a
-	}
-	return b
-}
-
-func max(a, b uint64) uint64 {
-	if a < b {
-		return b
-	}
-	return a
-}
-
 func properSubtraction(a, b uint64) uint64 {
 	if a < b {
 		return 0
@@ -30,14 +19,24 @@ func properSubtraction(a, b uint64) uint64 {
 }
 
 // ErrOverflow is used whenever addition of two uint64 would overflow.
-// This is an unlikely thing that few will ever need to consider so it is always reported via
+// This is an unlikely thing that few will ever need to consider so by default it is reported via
 //
 //	panic(ErrOverflow)
+//
+// The Try methods report it with an [*OverflowError] instead
+// and the Sat methods clamp the result to [math.MaxUint64].
 var ErrOverflow = errors.New("overflow")
 
-func sum(a, b uint64) uint64 {
+// add returns a + b and whether it overflowed.
+// It is the basis of sum, checkedSum, and satSum.
+func add(a, b uint64) (uint64, bool) {
 	v, over := bits.Add64(a, b, 0)
-	if over != 0 {
+	return v, over != 0
+}
+
+func sum(a, b uint64) uint64 {
+	v, over := add(a, b)
+	if over {
 		panic(ErrOverflow)
 	}
 	return v
@@ -47,10 +46,6 @@ func in(n uint64) bool {
 	return n > 0
 }
 
-func lt(x, y uint64) bool {
-	return x < y
-}
-
 func lte(x, y uint64) bool {
 	return x <= y
 }
@@ -69,14 +64,14 @@ type Of[K comparable] map[K]uint64
 //
 //	r[k] = max(m[k], o[k])
 func (m Of[K]) Union(o Of[K]) Of[K] {
-	return mapset.Union(m, o, in, max)
+	return mapset.Union(m, o, in, mapset.Max[uint64])
 }
 
 // Intersect chooses the minimum of multiplicities of both multisets.
 //
 //	r[k] = min(m[k], o[k])
 func (m Of[K]) Intersect(o Of[K]) Of[K] {
-	return mapset.Union(m, o, in, min)
+	return mapset.Intersect(m, o, in, mapset.Min[uint64])
 }
 
 // Add chooses the sum of multiplicities of both multisets.
@@ -87,11 +82,65 @@ func (m Of[K]) Add(o Of[K]) Of[K] {
 	return mapset.Union(m, o, in, sum)
 }
 
+// sub is the MergeWithFunc of Sub: the proper subtraction over the support of m.
+func sub[K comparable](_ K, l uint64, lok bool, r uint64, _ bool) (uint64, bool) {
+	if !lok {
+		return 0, false
+	}
+	v := properSubtraction(l, r)
+	return v, in(v)
+}
+
 // Sub chooses the proper subtraction of both multisets.
 //
 //	r[k] = max(m[k] - o[k], 0)
 func (m Of[K]) Sub(o Of[K]) Of[K] {
-	return mapset.Union(m, o, in, properSubtraction)
+	return mapset.MergeWith(m, o, sub[K])
+}
+
+// UnionAll is the n-ary form of Union.
+func (m Of[K]) UnionAll(os ...Of[K]) Of[K] {
+	return mapset.UnionAll(in, mapset.Max[uint64], append([]Of[K]{m}, os...)...)
+}
+
+// IntersectAll is the n-ary form of Intersect.
+func (m Of[K]) IntersectAll(os ...Of[K]) Of[K] {
+	return mapset.IntersectAll(in, mapset.Min[uint64], append([]Of[K]{m}, os...)...)
+}
+
+// AddAll is the n-ary form of Add.
+// It panics if any sum overflows.
+func (m Of[K]) AddAll(os ...Of[K]) Of[K] {
+	return mapset.UnionAll(in, sum, append([]Of[K]{m}, os...)...)
+}
+
+// UnionWith is the in-place form of Union.
+//
+//	m[k] = max(m[k], o[k])
+func (m Of[K]) UnionWith(o Of[K]) {
+	mapset.UnionInto(m, o, in, mapset.Max[uint64])
+}
+
+// IntersectWith is the in-place form of Intersect.
+//
+//	m[k] = min(m[k], o[k])
+func (m Of[K]) IntersectWith(o Of[K]) {
+	mapset.IntersectInPlace(m, o, in, mapset.Min[uint64])
+}
+
+// AddWith is the in-place form of Add.
+// It panics if any sum overflows.
+//
+//	m[k] = m[k] + o[k]
+func (m Of[K]) AddWith(o Of[K]) {
+	mapset.UnionInto(m, o, in, sum)
+}
+
+// SubWith is the in-place form of Sub.
+//
+//	m[k] = max(m[k] - o[k], 0)
+func (m Of[K]) SubWith(o Of[K]) {
+	mapset.MergeWithInPlace(m, o, sub[K])
 }
 
 // Inc adds x to m[k]. This panics if the addition overflows.
@@ -123,6 +172,14 @@ func (m Of[K]) Dec(k K, x uint64) uint64 {
 	return v
 }
 
+// ExtendSeq calls Inc(k, 1) for each k in seq.
+// This panics if any addition overflows.
+func (m Of[K]) ExtendSeq(seq iter.Seq[K]) {
+	for k := range seq {
+		m.Inc(k, 1)
+	}
+}
+
 // If n >= 0, call Inc(k, uint(n)); otherwise Dec(k, uint64(-n)).
 func (m Of[K]) IncDec(k K, n int) uint64 {
 	switch {
@@ -165,13 +222,17 @@ func (m Of[K]) Included(o Of[K]) bool {
 	return m.rel(o, lte)
 }
 
-// m is ProperIncluded in o if the multiplicity of each item in m is < the corresponding multiplicity in o.
+// m is ProperIncluded in o if m is Included in o and m is not Equal to o.
 //
 // true if, for all k:
 //
+//	m[k] <= o[k]
+//
+// and, for some k:
+//
 //	m[k] < o[k]
 func (m Of[K]) ProperIncluded(o Of[K]) bool {
-	return m.rel(o, lt)
+	return m.Included(o) && !m.Equal(o)
 }
 
 // true if, for all k:
@@ -191,11 +252,56 @@ func (m Of[K]) Cardinality() uint64 {
 	return n
 }
 
+// Diff returns the items of m whose keys are not in o.
+// The multiplicities of the remaining items are unchanged.
+// See Sub for the multiset difference.
+func (m Of[K]) Diff(o Of[K]) Of[K] {
+	return mapset.Diff(m, o, in)
+}
+
+// DiffAll returns the items of m whose keys are in none of os.
+// The multiplicities of the remaining items are unchanged.
+func (m Of[K]) DiffAll(os ...Of[K]) Of[K] {
+	return mapset.DiffAll(m, in, os...)
+}
+
+// SymDiff returns the items of m and o whose keys are in only one of them.
+// The multiplicities of the remaining items are unchanged.
+func (m Of[K]) SymDiff(o Of[K]) Of[K] {
+	return mapset.SymDiff(m, o, in)
+}
+
+// Disjoint reports whether no key is in both m and o.
+func (m Of[K]) Disjoint(o Of[K]) bool {
+	return mapset.Disjoint(m, o, in)
+}
+
+// Superset reports whether o is Included in m.
+func (m Of[K]) Superset(o Of[K]) bool {
+	return o.Included(m)
+}
+
+// ProperSuperset reports whether o is ProperIncluded in m.
+func (m Of[K]) ProperSuperset(o Of[K]) bool {
+	return o.ProperIncluded(m)
+}
+
+// Len is the number of distinct items in m.
+// See Cardinality for the number of items counting multiplicity.
+func (m Of[K]) Len() int {
+	return mapset.Len(m, in)
+}
+
 // Keys returns the support of m as a slice.
 func (m Of[K]) Keys() []K {
 	return mapset.Keys(m, in)
 }
 
+// Values returns the multiplicities of the items of m.
+func (m Of[K]) Values() []uint64 {
+	return mapset.Values(m, in)
+}
+
 func (m Of[K]) Clone() Of[K] {
 	return mapset.Clone(m, in)
 }
@@ -204,3 +310,43 @@ func (m Of[K]) Clone() Of[K] {
 func (m Of[K]) Purge() {
 	mapset.Purge(m, in)
 }
+
+// All returns an iterator over the items of m and their multiplicities.
+// Items of multiplicity 0 are skipped.
+func (m Of[K]) All() iter.Seq2[K, uint64] {
+	return mapset.All(m, in)
+}
+
+// Any returns an arbitrary item of m or false if m is empty.
+func (m Of[K]) Any() (K, bool) {
+	for k := range m.All() {
+		return k, true
+	}
+	var zero K
+	return zero, false
+}
+
+// Pop removes one occurrence of an arbitrary item of m and returns it, or false if m is empty.
+func (m Of[K]) Pop() (K, bool) {
+	k, ok := m.Any()
+	if ok {
+		m.Dec(k, 1)
+	}
+	return k, ok
+}
+
+// Filter returns a new multiset of the items of m for which keep returns true.
+func (m Of[K]) Filter(keep func(K) bool) Of[K] {
+	out := Of[K]{}
+	for k, v := range m.All() {
+		if keep(k) {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// Clear removes all items from m.
+func (m Of[K]) Clear() {
+	clear(m)
+}
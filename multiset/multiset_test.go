@@ -1,8 +1,13 @@
 package multiset
 
 import (
+	"errors"
 	"math"
+	"reflect"
 	"testing"
+
+	"github.com/jimmyfrasche/mapset"
+	"github.com/jimmyfrasche/mapset/internal/conformance"
 )
 
 func TestInc(t *testing.T) {
@@ -57,3 +62,109 @@ func TestDec(t *testing.T) {
 		t.Fatal("Dec should remove key if multiplicity hits 0")
 	}
 }
+
+func TestConformance(t *testing.T) {
+	sets := []Of[string]{
+		{},
+		{"a": 1},
+		{"a": 0},
+		{"a": 2, "b": 1},
+		{"b": 3, "c": 1},
+		{"a": 1, "b": 1, "c": 1},
+	}
+	conformance.Check(t, sets, in, mapset.Max[uint64], mapset.Min[uint64])
+
+	for _, x := range sets {
+		for _, y := range sets {
+			check := func(name string, got, want any) {
+				t.Helper()
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("%v.%s(%v): got %v, want %v", x, name, y, got, want)
+				}
+			}
+			check("Add", x.Add(y), mapset.Union(x, y, in, sum))
+			check("Sub", x.Sub(y), monus(x, y))
+			check("Superset", x.Superset(y), y.Included(x))
+			check("ProperSuperset", x.ProperSuperset(y), y.ProperIncluded(x))
+		}
+	}
+}
+
+// monus is the multiset difference of x and y worked out item by item.
+func monus(x, y Of[string]) Of[string] {
+	out := Of[string]{}
+	for k, n := range x {
+		if n > y[k] {
+			out[k] = n - y[k]
+		}
+	}
+	return out
+}
+
+func TestPop(t *testing.T) {
+	m := Of[string]{"a": 2}
+	for range 2 {
+		if k, ok := m.Pop(); !ok || k != "a" {
+			t.Fatal("Pop must return a")
+		}
+	}
+	if _, ok := m.Pop(); ok || len(m) != 0 {
+		t.Fatal("Pop must remove one occurrence at a time")
+	}
+}
+
+func TestTry(t *testing.T) {
+	m := Of[string]{"a": math.MaxUint64 - 1, "b": 1}
+
+	if _, err := m.TryInc("a", 1); err != nil {
+		t.Fatal(err)
+	}
+	v, err := m.TryInc("a", 1)
+	var oerr *OverflowError[string]
+	if !errors.As(err, &oerr) || !errors.Is(err, ErrOverflow) {
+		t.Fatalf("got %v, want *OverflowError", err)
+	}
+	if oerr.Key != "a" || oerr.A != math.MaxUint64 || oerr.B != 1 || v != math.MaxUint64 {
+		t.Fatalf("got %+v, %d", oerr, v)
+	}
+
+	if _, err := m.TryAdd(Of[string]{"b": 1, "c": 1}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = m.TryAdd(Of[string]{"a": 2, "c": 1})
+	if !errors.As(err, &oerr) || oerr.Key != "a" || oerr.A != math.MaxUint64 || oerr.B != 2 {
+		t.Fatalf("got %v, want overflow of a", err)
+	}
+
+	if _, err := m.TryCardinality(); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("got %v, want overflow", err)
+	}
+	if n, err := (Of[string]{"a": 1, "b": 2}).TryCardinality(); err != nil || n != 3 {
+		t.Fatalf("got %d, %v, want 3, nil", n, err)
+	}
+}
+
+func TestSat(t *testing.T) {
+	m := Of[string]{"a": math.MaxUint64 - 1, "b": 1}
+
+	if v := m.SatInc("a", 5); v != math.MaxUint64 {
+		t.Fatalf("SatInc = %d", v)
+	}
+	if r := m.SatAdd(Of[string]{"a": 1, "b": 1}); r["a"] != math.MaxUint64 || r["b"] != 2 {
+		t.Fatalf("SatAdd = %v", r)
+	}
+	if n := m.SatCardinality(); n != math.MaxUint64 {
+		t.Fatalf("SatCardinality = %d", n)
+	}
+}
+
+func TestOfNOverflow(t *testing.T) {
+	m := OfN[int, uint8]{0: 255}
+
+	defer func() {
+		if x := recover(); x != ErrOverflow {
+			t.Fatalf("got %v, want panic(ErrOverflow)", x)
+		}
+	}()
+	m.Inc(0, 1)
+}
@@ -0,0 +1,193 @@
+package multiset
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+
+	"github.com/jimmyfrasche/mapset"
+)
+
+// Count is the type of a multiplicity in an [OfN].
+type Count interface {
+	~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+func inN[N Count](n N) bool {
+	return n > 0
+}
+
+func sumN[N Count](a, b N) N {
+	v := a + b
+	// unsigned addition overflowed if and only if it wrapped around
+	if v < a {
+		panic(ErrOverflow)
+	}
+	return v
+}
+
+func properSubtractionN[N Count](a, b N) N {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
+
+// subN is the MergeWithFunc of OfN.Sub: the proper subtraction over the support of m.
+func subN[K comparable, N Count](_ K, l N, lok bool, r N, _ bool) (N, bool) {
+	if !lok {
+		return 0, false
+	}
+	v := properSubtractionN(l, r)
+	return v, inN(v)
+}
+
+// OfN is [Of] with multiplicities of type N.
+// Smaller multiplicities save memory when there are many keys with small counts.
+//
+// Operations that would overflow N panic with ErrOverflow.
+// An Of[K] can be converted to and from an OfN[K, uint64] directly.
+// Use [Resize] to convert between other widths.
+type OfN[K comparable, N Count] map[K]N
+
+// Union chooses the maximum of multiplicities of both multisets.
+func (m OfN[K, N]) Union(o OfN[K, N]) OfN[K, N] {
+	return mapset.Union(m, o, inN[N], mapset.Max[N])
+}
+
+// Intersect chooses the minimum of multiplicities of both multisets.
+func (m OfN[K, N]) Intersect(o OfN[K, N]) OfN[K, N] {
+	return mapset.Intersect(m, o, inN[N], mapset.Min[N])
+}
+
+// Add chooses the sum of multiplicities of both multisets.
+// It panics if any sum overflows N.
+func (m OfN[K, N]) Add(o OfN[K, N]) OfN[K, N] {
+	return mapset.Union(m, o, inN[N], sumN[N])
+}
+
+// Sub chooses the proper subtraction of both multisets.
+func (m OfN[K, N]) Sub(o OfN[K, N]) OfN[K, N] {
+	return mapset.MergeWith(m, o, subN[K, N])
+}
+
+// Inc adds x to m[k]. This panics if the addition overflows N.
+func (m OfN[K, N]) Inc(k K, x N) N {
+	y := m[k]
+	if x == 0 {
+		return y
+	}
+	v := sumN(y, x)
+	m[k] = v
+	return v
+}
+
+// Dec properly subtracts x from m[k].
+func (m OfN[K, N]) Dec(k K, x N) N {
+	y, ok := m[k]
+	if !ok {
+		return 0
+	}
+	if x == 0 {
+		return y
+	}
+	v := properSubtractionN(y, x)
+	if v == 0 {
+		delete(m, k)
+		return 0
+	}
+	m[k] = v
+	return v
+}
+
+// Contains k if m[k] > 0.
+func (m OfN[K, N]) Contains(k K) bool {
+	return m[k] > 0
+}
+
+// Included reports whether m[k] <= o[k] for all k.
+func (m OfN[K, N]) Included(o OfN[K, N]) bool {
+	for k, v := range m {
+		if v > o[k] {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether m[k] == o[k] for all k.
+func (m OfN[K, N]) Equal(o OfN[K, N]) bool {
+	return m.Included(o) && o.Included(m)
+}
+
+// Cardinality is the sum of the multiplicities of all items.
+// It panics if the sum overflows a uint64.
+func (m OfN[K, N]) Cardinality() uint64 {
+	var n uint64
+	for _, v := range m {
+		n = sum(n, uint64(v))
+	}
+	return n
+}
+
+// Len is the number of distinct items in m.
+func (m OfN[K, N]) Len() int {
+	return mapset.Len(m, inN[N])
+}
+
+// Keys returns the support of m as a slice.
+func (m OfN[K, N]) Keys() []K {
+	return mapset.Keys(m, inN[N])
+}
+
+func (m OfN[K, N]) Clone() OfN[K, N] {
+	return mapset.Clone(m, inN[N])
+}
+
+// Purge removes all keys of multiplicity 0.
+func (m OfN[K, N]) Purge() {
+	mapset.Purge(m, inN[N])
+}
+
+// All returns an iterator over the items of m and their multiplicities.
+// Items of multiplicity 0 are skipped.
+func (m OfN[K, N]) All() iter.Seq2[K, N] {
+	return mapset.All(m, inN[N])
+}
+
+// ErrTruncated is wrapped by a [*TruncationError].
+var ErrTruncated = errors.New("multiplicity truncated")
+
+// TruncationError records an item whose multiplicity does not fit in the width requested from [Resize].
+type TruncationError[K comparable] struct {
+	Key   K
+	Value uint64
+}
+
+func (e *TruncationError[K]) Error() string {
+	return fmt.Sprintf("%v: %d: %v", e.Key, e.Value, ErrTruncated)
+}
+
+func (e *TruncationError[K]) Unwrap() error {
+	return ErrTruncated
+}
+
+// Resize converts a multiset to one whose multiplicities have type To.
+// Items of multiplicity 0 are dropped.
+//
+// Both [Of] and [OfN] may be converted.
+// If any multiplicity does not fit in To, Resize returns a *TruncationError for it.
+func Resize[To Count, K comparable, From Count, M ~map[K]From](m M) (OfN[K, To], error) {
+	out := make(OfN[K, To], len(m))
+	for k, v := range m {
+		if v == 0 {
+			continue
+		}
+		t := To(v)
+		if From(t) != v {
+			return nil, &TruncationError[K]{k, uint64(v)}
+		}
+		out[k] = t
+	}
+	return out, nil
+}
@@ -0,0 +1,117 @@
+package multiset
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/jimmyfrasche/mapset"
+)
+
+// OverflowError records the key and operands of an addition that overflowed.
+//
+// errors.Is(err, ErrOverflow) is true for an *OverflowError.
+type OverflowError[K comparable] struct {
+	Key  K
+	A, B uint64
+}
+
+func (e *OverflowError[K]) Error() string {
+	return fmt.Sprintf("%v: %d + %d: %v", e.Key, e.A, e.B, ErrOverflow)
+}
+
+func (e *OverflowError[K]) Unwrap() error {
+	return ErrOverflow
+}
+
+// checkedSum is the MergeFuncErr of TryAdd.
+// The key is filled in by TryAdd.
+func checkedSum[K comparable](a, b uint64) (uint64, error) {
+	v, over := add(a, b)
+	if over {
+		return 0, &OverflowError[K]{A: a, B: b}
+	}
+	return v, nil
+}
+
+// inErr is in as a ContainsFuncErr.
+func inErr(n uint64) (bool, error) {
+	return in(n), nil
+}
+
+// satSum is the MergeFunc of SatAdd.
+func satSum(a, b uint64) uint64 {
+	v, over := add(a, b)
+	if over {
+		return math.MaxUint64
+	}
+	return v
+}
+
+// TryInc is Inc but returns an *OverflowError instead of panicking.
+// On error, m is unchanged and the current multiplicity of k is returned.
+func (m Of[K]) TryInc(k K, x uint64) (uint64, error) {
+	y := m[k]
+	v, over := add(y, x)
+	if over {
+		return y, &OverflowError[K]{k, y, x}
+	}
+	if x != 0 {
+		m[k] = v
+	}
+	return v, nil
+}
+
+// SatInc is Inc but clamps the result to math.MaxUint64 instead of panicking.
+func (m Of[K]) SatInc(k K, x uint64) uint64 {
+	y := m[k]
+	if x == 0 {
+		return y
+	}
+	v := satSum(y, x)
+	m[k] = v
+	return v
+}
+
+// TryAdd is Add but returns an *OverflowError for the first sum that overflows instead of panicking.
+func (m Of[K]) TryAdd(o Of[K]) (Of[K], error) {
+	r, err := mapset.UnionErr(m, o, inErr, checkedSum[K], mapset.StopOnError)
+	if err != nil {
+		var kerr *mapset.KeyError[K]
+		var oerr *OverflowError[K]
+		if errors.As(err, &kerr) && errors.As(err, &oerr) {
+			oerr.Key = kerr.Key
+			return nil, oerr
+		}
+		return nil, err
+	}
+	return r, nil
+}
+
+// SatAdd is Add but clamps each sum to math.MaxUint64 instead of panicking.
+func (m Of[K]) SatAdd(o Of[K]) Of[K] {
+	return mapset.Union(m, o, in, satSum)
+}
+
+// TryCardinality is Cardinality but returns an *OverflowError instead of panicking.
+// The key of the error is the item whose multiplicity caused the overflow.
+func (m Of[K]) TryCardinality() (uint64, error) {
+	var n uint64
+	for k, v := range m {
+		x, over := add(n, v)
+		if over {
+			return 0, &OverflowError[K]{k, n, v}
+		}
+		n = x
+	}
+	return n, nil
+}
+
+// SatCardinality is Cardinality but clamps the result to math.MaxUint64 instead of panicking.
+func (m Of[K]) SatCardinality() uint64 {
+	var n uint64
+	for _, v := range m {
+		n = satSum(n, v)
+	}
+	return n
+}
@@ -0,0 +1,158 @@
+package multiset
+
+import (
+	"math"
+
+	"github.com/jimmyfrasche/mapset"
+)
+
+// nonZero is the ContainsFunc of Z.
+func nonZero(n int64) bool {
+	return n != 0
+}
+
+// sumZ returns a + b, panicking with ErrOverflow if the addition overflows.
+func sumZ(a, b int64) int64 {
+	v := a + b
+	// signed addition overflowed if and only if both operands have the same sign and the result does not
+	if (a >= 0) == (b >= 0) && (v >= 0) != (a >= 0) {
+		panic(ErrOverflow)
+	}
+	return v
+}
+
+// diffZ returns a - b, panicking with ErrOverflow if the subtraction overflows.
+func diffZ(a, b int64) int64 {
+	v := a - b
+	// signed subtraction overflowed if and only if the operands have different signs and the result does not have the sign of a
+	if (a >= 0) != (b >= 0) && (v >= 0) != (a >= 0) {
+		panic(ErrOverflow)
+	}
+	return v
+}
+
+// negZ returns -a, panicking with ErrOverflow if a is math.MinInt64.
+func negZ(a int64) int64 {
+	if a == math.MinInt64 {
+		panic(ErrOverflow)
+	}
+	return -a
+}
+
+// subZ is the MergeWithFunc of Z.Sub.
+// Absent weights are 0.
+func subZ[K comparable](_ K, l int64, _ bool, r int64, _ bool) (int64, bool) {
+	v := diffZ(l, r)
+	return v, nonZero(v)
+}
+
+// Z[K] is a signed multiset, or Z-set, of K: each key has an integer weight that may be negative.
+// Z-sets represent changes to a multiset, as in incremental view maintenance,
+// where a positive weight is an insertion and a negative weight is a deletion.
+//
+// A weight of 0 is the same as not being in the set
+// and the operations of Z never produce keys with a weight of 0.
+//
+// Operations that would overflow an int64 panic with ErrOverflow.
+type Z[K comparable] map[K]int64
+
+// Add sums the weights of both Z-sets.
+//
+//	r[k] = z[k] + o[k]
+func (z Z[K]) Add(o Z[K]) Z[K] {
+	return mapset.Union(z, o, nonZero, sumZ)
+}
+
+// Sub subtracts the weights of o from z.
+//
+//	r[k] = z[k] - o[k]
+func (z Z[K]) Sub(o Z[K]) Z[K] {
+	return mapset.MergeWith(z, o, subZ[K])
+}
+
+// Negate flips the sign of every weight.
+//
+//	r[k] = -z[k]
+func (z Z[K]) Negate() Z[K] {
+	out := make(Z[K], len(z))
+	for k, v := range mapset.All(z, nonZero) {
+		out[k] = negZ(v)
+	}
+	return out
+}
+
+// Positive keeps the keys with a positive weight.
+//
+//	r[k] = max(z[k], 0)
+func (z Z[K]) Positive() Z[K] {
+	return mapset.Clone(z, mapset.GreaterThan[int64](0))
+}
+
+// Distinct sets the weight of each key with a positive weight to 1 and drops the rest.
+//
+//	r[k] = 1 if z[k] > 0 else 0
+func (z Z[K]) Distinct() Z[K] {
+	out := Z[K]{}
+	for k := range mapset.KeysSeq(z, mapset.GreaterThan[int64](0)) {
+		out[k] = 1
+	}
+	return out
+}
+
+// Update adds w to z[k], removing k if its weight becomes 0, and returns the new weight.
+// It panics if the addition overflows.
+func (z Z[K]) Update(k K, w int64) int64 {
+	v := sumZ(z[k], w)
+	if v == 0 {
+		delete(z, k)
+	} else {
+		z[k] = v
+	}
+	return v
+}
+
+// Contains k if z[k] != 0.
+func (z Z[K]) Contains(k K) bool {
+	return z[k] != 0
+}
+
+// Equal reports whether z[k] == o[k] for all k.
+func (z Z[K]) Equal(o Z[K]) bool {
+	return mapset.EqualValues(z, o, nonZero)
+}
+
+// Keys returns the keys of z with a nonzero weight.
+func (z Z[K]) Keys() []K {
+	return mapset.Keys(z, nonZero)
+}
+
+func (z Z[K]) Clone() Z[K] {
+	return mapset.Clone(z, nonZero)
+}
+
+// Purge removes all keys of weight 0.
+func (z Z[K]) Purge() {
+	mapset.Purge(z, nonZero)
+}
+
+// ToOf converts the positive part of z to a multiset.
+func (z Z[K]) ToOf() Of[K] {
+	out := Of[K]{}
+	for k, v := range mapset.All(z, mapset.GreaterThan[int64](0)) {
+		out[k] = uint64(v)
+	}
+	return out
+}
+
+// FromOf converts a multiset to a Z-set.
+// It panics with ErrOverflow if a multiplicity is greater than math.MaxInt64.
+func FromOf[K comparable](m Of[K]) Z[K] {
+	out := make(Z[K], len(m))
+	for k, v := range m.All() {
+		if v > math.MaxInt64 {
+			panic(ErrOverflow)
+		}
+		out[k] = int64(v)
+	}
+	return out
+}
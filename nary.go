@@ -0,0 +1,81 @@
+package mapset
+
+// UnionAll creates a new M that is the union of all of ms.
+//
+// The result is the same as folding [Union] over ms from left to right:
+// when a key is in more than one map, its values are merged in the order the maps are given,
+// each value must pass the [ContainsFunc] check,
+// and a key is dropped when a merged value fails the [ContainsFunc] check,
+// though a later map may add it back.
+//
+// Unlike a chain of calls to Union, no intermediate maps are allocated.
+func UnionAll[K comparable, V any, M ~map[K]V](contains ContainsFunc[V], merge MergeFunc[V], ms ...M) M {
+	if len(ms) == 0 {
+		return M{}
+	}
+	out := Clone(ms[0], contains)
+	for _, m := range ms[1:] {
+		unionInto(out, m, contains, merge)
+	}
+	return out
+}
+
+// IntersectAll creates a new M that is the intersection of all of ms.
+//
+// The result is the same as folding [Intersect] over ms from left to right:
+// the values of a key are merged in the order the maps are given
+// and every value and every merged value must pass the [ContainsFunc] check.
+//
+// Only the keys of the smallest map are considered, and no intermediate maps are allocated.
+func IntersectAll[K comparable, V any, M ~map[K]V](contains ContainsFunc[V], merge MergeFunc[V], ms ...M) M {
+	out := M{}
+	if len(ms) == 0 {
+		return out
+	}
+	smallest := ms[0]
+	for _, m := range ms[1:] {
+		if len(m) < len(smallest) {
+			smallest = m
+		}
+	}
+keys:
+	for k := range smallest {
+		var acc V
+		for i, m := range ms {
+			v, ok := m[k]
+			if !ok || !contains.Check(v) {
+				continue keys
+			}
+			if i == 0 {
+				acc = v
+				continue
+			}
+			acc = merge.Into(acc, v)
+			if !contains.Check(acc) {
+				continue keys
+			}
+		}
+		out[k] = acc
+	}
+	return out
+}
+
+// DiffAll creates a new M with the items of lhs that pass the [ContainsFunc] check and are not in any of rhs.
+//
+// The result is the same as folding [Diff] over rhs starting with lhs.
+func DiffAll[K comparable, V any, M ~map[K]V](lhs M, contains ContainsFunc[V], rhs ...M) M {
+	out := M{}
+items:
+	for k, v := range lhs {
+		if !contains.Check(v) {
+			continue
+		}
+		for _, m := range rhs {
+			if Contains(m, k, contains) {
+				continue items
+			}
+		}
+		out[k] = v
+	}
+	return out
+}
@@ -5,53 +5,64 @@ package mapset
 // When an item exists in both maps the pair of values is merged and added to the result map
 // if the merged value passes the [ContainsFunc] check.
 func Union[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFunc[V], merge MergeFunc[V]) M {
-	out := M{}
-	for k, v := range lhs {
-		if contains.Check(v) {
-			out[k] = v
+	return MergeWith(lhs, rhs, func(_ K, l V, lok bool, r V, rok bool) (V, bool) {
+		lok = lok && contains.Check(l)
+		rok = rok && contains.Check(r)
+		if lok && rok {
+			// key exists in both maps, do a merge
+			v := merge.Into(l, r)
+			// make sure two valid entries aren't merged into an invalid entry
+			return v, contains.Check(v)
 		}
-	}
-	for k, v := range rhs {
-		if contains.Check(v) {
-			if L, ok := out[k]; !ok {
-				// new key, add
-				out[k] = v
-			} else {
-				// key exists in both maps, do a merge
-				v := merge.Into(L, v)
-
-				out[k] = v
-				// make sure two valid entries aren't merged into an invalid entry
-				if !contains.Check(v) {
-					delete(out, k)
-				}
-			}
+		if lok {
+			return l, true
 		}
-	}
-	return out
+		return r, rok
+	})
 }
 
 // Intersect creates a new M that is the intersection of lhs and rhs.
 // All pairs values pass [ContainsFunc], are merged, and the result passes the [ContainsFunc] for inclusion.
 func Intersect[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFunc[V], merge MergeFunc[V]) M {
-	out := M{}
+	out := make(M, min(len(lhs), len(rhs)))
+	intersect(lhs, rhs, contains, merge, func(k K, v V) {
+		out[k] = v
+	})
+	return out
+}
+
+// intersect calls f with each item of the intersection of lhs and rhs.
+// It iterates over the smaller of the two maps
+// but always merges values in lhs, rhs order.
+func intersect[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFunc[V], merge MergeFunc[V], f func(K, V)) {
+	if len(rhs) < len(lhs) {
+		for k, vp := range rhs {
+			v, ok := lhs[k]
+			if ok && contains.Check(v) && contains.Check(vp) {
+				vf := merge.Into(v, vp)
+				if contains.Check(vf) {
+					f(k, vf)
+				}
+			}
+		}
+		return
+	}
 	for k, v := range lhs {
 		vp, ok := rhs[k]
 		if ok && contains.Check(v) && contains.Check(vp) {
 			vf := merge.Into(v, vp)
 			if contains.Check(vf) {
-				out[k] = vf
+				f(k, vf)
 			}
 		}
 	}
-	return out
 }
 
 // Diff is the set difference of lhs and rhs.
 // The result contains all items in lhs that pass the [ContainsFunc] check that are not in rhs.
 // Diff is also known as: relative complement, kick out, or except.
 func Diff[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFunc[V]) M {
-	out := M{}
+	out := make(M, len(lhs))
 	for k, v := range lhs {
 		if contains.Check(v) && !Contains(rhs, k, contains) {
 			out[k] = v
@@ -63,24 +74,24 @@ func Diff[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFunc[V])
 // SymDiff is the symmetric difference of lhs and rhs.
 // The results contains the values in lhs and rhs but not in both, according to the [ContainsFunc] check.
 func SymDiff[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFunc[V]) M {
-	out := M{}
-	for k, v := range lhs {
-		// k in lhs but not rhs
-		if contains.Check(v) && !Contains(rhs, k, contains) {
-			out[k] = v
+	return MergeWith(lhs, rhs, func(_ K, l V, lok bool, r V, rok bool) (V, bool) {
+		lok = lok && contains.Check(l)
+		rok = rok && contains.Check(r)
+		if lok {
+			// k in lhs but not rhs
+			return l, !rok
 		}
-	}
-	for k, v := range rhs {
 		// k in rhs but not lhs
-		if contains.Check(v) && !Contains(lhs, k, contains) {
-			out[k] = v
-		}
-	}
-	return out
+		return r, rok
+	})
 }
 
 // Disjoint returns true if no keys of lhs are present in rhs.
 func Disjoint[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFunc[V]) bool {
+	// disjointness is symmetric so only the smaller map needs to be iterated
+	if len(rhs) < len(lhs) {
+		lhs, rhs = rhs, lhs
+	}
 	for k, v := range lhs {
 		if contains.Check(v) && Contains(rhs, k, contains) {
 			return false
@@ -89,25 +100,28 @@ func Disjoint[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFunc
 	return true
 }
 
+// subset reports whether lhs is a subset of rhs and, if so, the number of items in lhs.
 func subset[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFunc[V]) (int, bool) {
-	len := 0
+	n := 0
 	for k, v := range lhs {
-		if contains.Check(v) && !Contains(rhs, k, contains) {
+		if !contains.Check(v) {
+			continue
+		}
+		if !Contains(rhs, k, contains) {
 			return 0, false
 		}
-		len++
+		n++
 	}
-	return len, true
+	return n, true
 }
 
 // Equal tests equality of the keys whose values pass the [ContainsFunc] check. The values are otherwise not considered.
+//
+// See [EqualFunc] to also compare values.
 func Equal[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFunc[V]) bool {
-	m, ok := subset(lhs, rhs, contains)
-	if !ok {
-		return false
-	}
-	n, ok := subset(rhs, lhs, contains)
-	return ok && m == n
+	n, ok := subset(lhs, rhs, contains)
+	// lhs is a subset of rhs so they are equal if and only if they are the same size
+	return ok && n == Len(rhs, contains)
 }
 
 // Subset tests whether lhs is a Subset of rhs.
@@ -119,15 +133,8 @@ func Subset[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFunc[V
 // ProperSubset checks that lhs Subset rhs but not lhs Equal rhs.
 func ProperSubset[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFunc[V]) bool {
 	n, ok := subset(lhs, rhs, contains)
-	if !ok {
-		return false
-	}
-	// can only be equal
-	if len(rhs) == n {
-		return false
-	}
-	_, ok = subset(rhs, lhs, contains)
-	return !ok
+	// lhs is a subset of rhs so rhs has extra items if and only if it is larger
+	return ok && n < Len(rhs, contains)
 }
 
 // Len counts the keys in m whose values pass the [ContainsFunc] check.
@@ -141,9 +148,58 @@ func Len[K comparable, V any, M ~map[K]V](m M, contains ContainsFunc[V]) int {
 	return n
 }
 
+// UnionLen counts the items in Union(lhs, rhs, contains, merge) without creating it.
+func UnionLen[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFunc[V], merge MergeFunc[V]) int {
+	var n int
+	for k, v := range lhs {
+		if !contains.Check(v) {
+			continue
+		}
+		if vp, ok := rhs[k]; ok && contains.Check(vp) {
+			// key exists in both maps, only counted if the merge is valid
+			if contains.Check(merge.Into(v, vp)) {
+				n++
+			}
+			continue
+		}
+		n++
+	}
+	for k, v := range rhs {
+		if contains.Check(v) && !Contains(lhs, k, contains) {
+			n++
+		}
+	}
+	return n
+}
+
+// IntersectLen counts the items in Intersect(lhs, rhs, contains, merge) without creating it.
+func IntersectLen[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFunc[V], merge MergeFunc[V]) int {
+	var n int
+	intersect(lhs, rhs, contains, merge, func(K, V) {
+		n++
+	})
+	return n
+}
+
+// DiffLen counts the items in Diff(lhs, rhs, contains) without creating it.
+func DiffLen[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFunc[V]) int {
+	var n int
+	for k, v := range lhs {
+		if contains.Check(v) && !Contains(rhs, k, contains) {
+			n++
+		}
+	}
+	return n
+}
+
+// SymDiffLen counts the items in SymDiff(lhs, rhs, contains) without creating it.
+func SymDiffLen[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFunc[V]) int {
+	return DiffLen(lhs, rhs, contains) + DiffLen(rhs, lhs, contains)
+}
+
 // Clone returns a copy of M without items that fail the [ContainsFunc] check.
 func Clone[K comparable, V any, M ~map[K]V](m M, contains ContainsFunc[V]) M {
-	out := M{}
+	out := make(M, len(m))
 	for k, v := range m {
 		if contains.Check(v) {
 			out[k] = v
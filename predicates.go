@@ -0,0 +1,152 @@
+package mapset
+
+import "cmp"
+
+// Always returns true.
+// It is equivalent to a nil [ContainsFunc].
+func Always[V any](V) bool {
+	return true
+}
+
+// Never returns false.
+func Never[V any](V) bool {
+	return false
+}
+
+// NonZero returns true if v is not the zero value of V.
+//
+// NonZero accepts the same values as the [ContainsFunc] used by [Bool] and multiset.Of.
+func NonZero[V comparable](v V) bool {
+	var zero V
+	return v != zero
+}
+
+// NonNil returns true unless v is nil.
+func NonNil(v any) bool {
+	return v != nil
+}
+
+// GreaterThan returns a [ContainsFunc] that accepts values greater than x.
+func GreaterThan[V cmp.Ordered](x V) ContainsFunc[V] {
+	return func(v V) bool {
+		return cmp.Compare(v, x) > 0
+	}
+}
+
+// LessThan returns a [ContainsFunc] that accepts values less than x.
+func LessThan[V cmp.Ordered](x V) ContainsFunc[V] {
+	return func(v V) bool {
+		return cmp.Compare(v, x) < 0
+	}
+}
+
+// ValueIn returns a [ContainsFunc] that accepts values in s.
+func ValueIn[V comparable](s Set[V]) ContainsFunc[V] {
+	return s.Contains
+}
+
+// nonNil returns cs without any nil ContainsFunc.
+func nonNil[V any](cs []ContainsFunc[V]) []ContainsFunc[V] {
+	var out []ContainsFunc[V]
+	for _, c := range cs {
+		if c != nil {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// And returns a [ContainsFunc] that accepts values that pass every check in cs.
+// The checks are made in order and stop at the first failure.
+//
+// As nil always returns true, nil entries in cs are ignored
+// and And returns nil if there are no other entries.
+func And[V any](cs ...ContainsFunc[V]) ContainsFunc[V] {
+	cs = nonNil(cs)
+	switch len(cs) {
+	case 0:
+		return nil
+	case 1:
+		return cs[0]
+	}
+	return func(v V) bool {
+		for _, c := range cs {
+			if !c(v) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a [ContainsFunc] that accepts values that pass any check in cs.
+// The checks are made in order and stop at the first success.
+//
+// As nil always returns true, Or returns nil if any entry in cs is nil.
+// Or with no entries returns [Never].
+func Or[V any](cs ...ContainsFunc[V]) ContainsFunc[V] {
+	if len(cs) == 0 {
+		return Never[V]
+	}
+	for _, c := range cs {
+		if c == nil {
+			return nil
+		}
+	}
+	if len(cs) == 1 {
+		return cs[0]
+	}
+	return func(v V) bool {
+		for _, c := range cs {
+			if c(v) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a [ContainsFunc] that accepts the values c rejects.
+// Not(nil) returns [Never].
+func Not[V any](c ContainsFunc[V]) ContainsFunc[V] {
+	if c == nil {
+		return Never[V]
+	}
+	return func(v V) bool {
+		return !c(v)
+	}
+}
+
+// Xor returns a [ContainsFunc] that accepts values that pass an odd number of the checks in cs.
+// Every check is made and nil entries always pass.
+func Xor[V any](cs ...ContainsFunc[V]) ContainsFunc[V] {
+	return func(v V) bool {
+		odd := false
+		for _, c := range cs {
+			if c.Check(v) {
+				odd = !odd
+			}
+		}
+		return odd
+	}
+}
+
+// Memoize returns a [ContainsFunc] that calls c at most once for each distinct value
+// and remembers the result.
+// Memoize(nil) returns nil.
+//
+// The returned ContainsFunc is not safe for concurrent use.
+func Memoize[V comparable](c ContainsFunc[V]) ContainsFunc[V] {
+	if c == nil {
+		return nil
+	}
+	seen := map[V]bool{}
+	return func(v V) bool {
+		r, ok := seen[v]
+		if !ok {
+			r = c(v)
+			seen[v] = r
+		}
+		return r
+	}
+}
@@ -0,0 +1,112 @@
+package mapset
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Action is what a [Step] of a reconciliation does.
+type Action int
+
+const (
+	// ActionRemove removes a key that is only in actual.
+	ActionRemove Action = iota
+	// ActionUpdate updates a key whose desired and actual values differ.
+	ActionUpdate
+	// ActionAdd adds a key that is only in desired.
+	ActionAdd
+)
+
+func (a Action) String() string {
+	switch a {
+	case ActionRemove:
+		return "remove"
+	case ActionUpdate:
+		return "update"
+	case ActionAdd:
+		return "add"
+	}
+	return fmt.Sprintf("Action(%d)", int(a))
+}
+
+// Step is a single action that [Reconcile] takes.
+// Desired is only set for updates and additions and Actual for updates and removals.
+type Step[K comparable, V any] struct {
+	Action  Action
+	Key     K
+	Desired V
+	Actual  V
+}
+
+// Handlers are the callbacks [Reconcile] makes for each [Step].
+// A nil handler is skipped.
+type Handlers[K comparable, V any] struct {
+	OnAdd    func(key K, desired V) error
+	OnUpdate func(key K, desired, actual V) error
+	OnRemove func(key K, actual V) error
+}
+
+// ReconcileOptions control how [Reconcile] executes its plan.
+// The zero value runs every step in no particular order and stops at the first error.
+type ReconcileOptions[K comparable] struct {
+	// Compare, if not nil, orders the keys of each action.
+	Compare func(a, b K) int
+	// OnError decides whether to stop at the first error or to run every step and join the errors.
+	OnError OnError
+	// DryRun computes the plan without calling any handlers.
+	DryRun bool
+}
+
+// Reconcile makes actual match desired by calling the handlers in h.
+//
+// The plan is computed from DiffReport(actual, desired, contains, eq),
+// so only items whose values pass the [ContainsFunc] check are considered,
+// and a nil eq updates every key in both maps.
+// All removals are run first, then all updates, then all additions.
+//
+// Reconcile returns the full plan, even if it stops early.
+// Any error returned by a handler is wrapped in a [KeyError].
+func Reconcile[K comparable, V any, M ~map[K]V](desired, actual M, contains ContainsFunc[V], eq func(V, V) bool, h Handlers[K, V], opts ReconcileOptions[K]) ([]Step[K, V], error) {
+	d := DiffReport(actual, desired, contains, eq)
+
+	keys := func(ks []K) []K {
+		if opts.Compare != nil {
+			slices.SortFunc(ks, opts.Compare)
+		}
+		return ks
+	}
+
+	plan := make([]Step[K, V], 0, len(d.Removed)+len(d.Changed)+len(d.Added))
+	for _, k := range keys(slices.Collect(maps.Keys(d.Removed))) {
+		plan = append(plan, Step[K, V]{Action: ActionRemove, Key: k, Actual: d.Removed[k]})
+	}
+	for _, k := range keys(slices.Collect(maps.Keys(d.Changed))) {
+		c := d.Changed[k]
+		plan = append(plan, Step[K, V]{Action: ActionUpdate, Key: k, Desired: c.New, Actual: c.Old})
+	}
+	for _, k := range keys(slices.Collect(maps.Keys(d.Added))) {
+		plan = append(plan, Step[K, V]{Action: ActionAdd, Key: k, Desired: d.Added[k]})
+	}
+
+	if opts.DryRun {
+		return plan, nil
+	}
+
+	e := &errs[K]{onErr: opts.OnError}
+	for _, s := range plan {
+		var err error
+		switch {
+		case s.Action == ActionRemove && h.OnRemove != nil:
+			err = h.OnRemove(s.Key, s.Actual)
+		case s.Action == ActionUpdate && h.OnUpdate != nil:
+			err = h.OnUpdate(s.Key, s.Desired, s.Actual)
+		case s.Action == ActionAdd && h.OnAdd != nil:
+			err = h.OnAdd(s.Key, s.Desired)
+		}
+		if err != nil && e.add(s.Key, err) {
+			break
+		}
+	}
+	return plan, e.err()
+}
@@ -0,0 +1,156 @@
+package mapset
+
+import "iter"
+
+// All returns an iterator over the key-value pairs in m whose values pass the [ContainsFunc] check.
+func All[K comparable, V any, M ~map[K]V](m M, contains ContainsFunc[V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range m {
+			if contains.Check(v) && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// KeysSeq returns an iterator over the keys in m whose values pass the [ContainsFunc] check.
+//
+// KeysSeq is the lazy form of [Keys].
+func KeysSeq[K comparable, V any, M ~map[K]V](m M, contains ContainsFunc[V]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k, v := range m {
+			if contains.Check(v) && !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// ValuesSeq returns an iterator over the values in m that pass the [ContainsFunc] check.
+//
+// ValuesSeq is the lazy form of [Values].
+func ValuesSeq[K comparable, V any, M ~map[K]V](m M, contains ContainsFunc[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m {
+			if contains.Check(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// UnionSeq returns an iterator over the union of lhs and rhs.
+// It yields the same entries as [Union] without allocating a result map.
+func UnionSeq[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFunc[V], merge MergeFunc[V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range lhs {
+			if !contains.Check(v) {
+				continue
+			}
+			if R, ok := rhs[k]; ok && contains.Check(R) {
+				// key exists in both maps, do a merge
+				v = merge.Into(v, R)
+				// make sure two valid entries aren't merged into an invalid entry
+				if !contains.Check(v) {
+					continue
+				}
+			}
+			if !yield(k, v) {
+				return
+			}
+		}
+		for k, v := range rhs {
+			// keys in both maps were handled above
+			if contains.Check(v) && !Contains(lhs, k, contains) {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// IntersectSeq returns an iterator over the intersection of lhs and rhs.
+// It yields the same entries as [Intersect] without allocating a result map.
+func IntersectSeq[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFunc[V], merge MergeFunc[V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range lhs {
+			vp, ok := rhs[k]
+			if ok && contains.Check(v) && contains.Check(vp) {
+				vf := merge.Into(v, vp)
+				if contains.Check(vf) && !yield(k, vf) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// DiffSeq returns an iterator over the set difference of lhs and rhs.
+// It yields the same entries as [Diff] without allocating a result map.
+func DiffSeq[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFunc[V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range lhs {
+			if contains.Check(v) && !Contains(rhs, k, contains) {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// SymDiffSeq returns an iterator over the symmetric difference of lhs and rhs.
+// It yields the same entries as [SymDiff] without allocating a result map.
+func SymDiffSeq[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFunc[V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range DiffSeq(lhs, rhs, contains) {
+			if !yield(k, v) {
+				return
+			}
+		}
+		for k, v := range DiffSeq(rhs, lhs, contains) {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// FromSeq creates a new M from the key-value pairs of seq.
+//
+// See [CollectInto] for how the pairs are added.
+func FromSeq[M ~map[K]V, K comparable, V any](seq iter.Seq2[K, V], contains ContainsFunc[V], merge MergeFunc[V]) M {
+	out := M{}
+	CollectInto(out, seq, contains, merge)
+	return out
+}
+
+// CollectInto adds the key-value pairs of seq to dst.
+// Pairs whose values fail the [ContainsFunc] check are skipped.
+// When a key is already in dst, the existing value and the new value are merged
+// and the key is removed from dst if the merged value fails the [ContainsFunc] check.
+//
+// As with [UnionInto], the items of dst that fail the [ContainsFunc] check are removed first,
+// so this matches the rules of [Union] with dst as lhs.
+func CollectInto[K comparable, V any, M ~map[K]V](dst M, seq iter.Seq2[K, V], contains ContainsFunc[V], merge MergeFunc[V]) {
+	Purge(dst, contains)
+	for k, v := range seq {
+		if !contains.Check(v) {
+			continue
+		}
+		L, ok := dst[k]
+		if !ok {
+			// new key, add
+			dst[k] = v
+			continue
+		}
+		// key exists in both, do a merge
+		v = merge.Into(L, v)
+		if contains.Check(v) {
+			dst[k] = v
+		} else {
+			delete(dst, k)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package mapset
 
+import "iter"
+
 // Set provides all relevant set methods with nil [ContainsFunc] and [MergeFunc].
 type Set[K comparable] map[K]struct{}
 
@@ -10,6 +12,13 @@ func (s Set[K]) Extend(keys ...K) {
 	}
 }
 
+// ExtendSeq adds the keys of seq to s.
+func (s Set[K]) ExtendSeq(seq iter.Seq[K]) {
+	for k := range seq {
+		s[k] = struct{}{}
+	}
+}
+
 // Add key and reports whether it is new.
 func (s Set[K]) Add(key K) bool {
 	seen := s.Contains(key)
@@ -47,6 +56,41 @@ func (s Set[K]) SymDiff(o Set[K]) Set[K] {
 	return SymDiff(s, o, nil)
 }
 
+// UnionWith adds the keys of o to s.
+func (s Set[K]) UnionWith(o Set[K]) {
+	UnionInto(s, o, nil, nil)
+}
+
+// IntersectWith removes the keys of s that are not in o.
+func (s Set[K]) IntersectWith(o Set[K]) {
+	IntersectInPlace(s, o, nil, nil)
+}
+
+// DiffWith removes the keys of o from s.
+func (s Set[K]) DiffWith(o Set[K]) {
+	DiffInPlace(s, o, nil)
+}
+
+// SymDiffWith sets s to the symmetric difference of s and o.
+func (s Set[K]) SymDiffWith(o Set[K]) {
+	SymDiffInPlace(s, o, nil)
+}
+
+// UnionAll returns the union of s and all of os.
+func (s Set[K]) UnionAll(os ...Set[K]) Set[K] {
+	return UnionAll(nil, nil, append([]Set[K]{s}, os...)...)
+}
+
+// IntersectAll returns the intersection of s and all of os.
+func (s Set[K]) IntersectAll(os ...Set[K]) Set[K] {
+	return IntersectAll(nil, nil, append([]Set[K]{s}, os...)...)
+}
+
+// DiffAll returns the keys of s that are in none of os.
+func (s Set[K]) DiffAll(os ...Set[K]) Set[K] {
+	return DiffAll(s, nil, os...)
+}
+
 func (s Set[K]) Contains(k K) bool {
 	_, ok := s[k]
 	return ok
@@ -67,3 +111,72 @@ func (s Set[K]) Disjoint(o Set[K]) bool {
 func (s Set[K]) ProperSubset(o Set[K]) bool {
 	return ProperSubset(s, o, nil)
 }
+
+// All returns an iterator over the keys of s.
+func (s Set[K]) All() iter.Seq[K] {
+	return KeysSeq(s, nil)
+}
+
+func (s Set[K]) Superset(o Set[K]) bool {
+	return o.Subset(s)
+}
+
+func (s Set[K]) ProperSuperset(o Set[K]) bool {
+	return o.ProperSubset(s)
+}
+
+func (s Set[K]) Len() int {
+	return Len(s, nil)
+}
+
+func (s Set[K]) Clone() Set[K] {
+	return Clone(s, nil)
+}
+
+func (s Set[K]) Keys() []K {
+	return Keys(s, nil)
+}
+
+// Values returns a struct{}{} for each key of s.
+func (s Set[K]) Values() []struct{} {
+	return Values(s, nil)
+}
+
+// Purge does nothing as every key of s is in the set.
+func (s Set[K]) Purge() {
+	Purge(s, nil)
+}
+
+// Any returns an arbitrary key of s or false if s is empty.
+func (s Set[K]) Any() (K, bool) {
+	for k := range s.All() {
+		return k, true
+	}
+	var zero K
+	return zero, false
+}
+
+// Pop removes and returns an arbitrary key of s or false if s is empty.
+func (s Set[K]) Pop() (K, bool) {
+	k, ok := s.Any()
+	if ok {
+		delete(s, k)
+	}
+	return k, ok
+}
+
+// Filter returns a new Set of the keys of s for which keep returns true.
+func (s Set[K]) Filter(keep func(K) bool) Set[K] {
+	out := Set[K]{}
+	for k := range s.All() {
+		if keep(k) {
+			out[k] = s[k]
+		}
+	}
+	return out
+}
+
+// Clear removes all keys from s.
+func (s Set[K]) Clear() {
+	clear(s)
+}
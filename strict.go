@@ -0,0 +1,41 @@
+package mapset
+
+// Conflict records a key whose values differ between the two maps given to [UnionStrict].
+type Conflict[K comparable, V any] struct {
+	Key      K
+	Lhs, Rhs V
+}
+
+// UnionStrict creates a new M that is the union of lhs and rhs,
+// reporting every key that is in both maps with values that are not equal.
+//
+// For an item of either map to be included the value of the item must pass the [ContainsFunc] check.
+// When a key is in both maps the value from lhs is used, as with a nil [MergeFunc],
+// and a [Conflict] is recorded unless equal reports the values as equal.
+// Conflicts are reported in no particular order.
+//
+// A nil equal treats all values as unequal, as with [DiffReport], so every key in both maps is reported.
+func UnionStrict[K comparable, V any, M ~map[K]V](lhs, rhs M, contains ContainsFunc[V], equal func(V, V) bool) (M, []Conflict[K, V]) {
+	var conflicts []Conflict[K, V]
+	out := make(M, max(len(lhs), len(rhs)))
+	for k, v := range lhs {
+		if contains.Check(v) {
+			out[k] = v
+		}
+	}
+	for k, v := range rhs {
+		if !contains.Check(v) {
+			continue
+		}
+		L, ok := out[k]
+		if !ok {
+			// new key, add
+			out[k] = v
+			continue
+		}
+		if equal == nil || !equal(L, v) {
+			conflicts = append(conflicts, Conflict[K, V]{k, L, v})
+		}
+	}
+	return out, conflicts
+}
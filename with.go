@@ -0,0 +1,114 @@
+package mapset
+
+import "iter"
+
+// With binds a map to the [ContainsFunc] and [MergeFunc] that define it as a set.
+// Its methods are the operations of this package with M, Contains, and Merge filled in,
+// which provides what [Set] and [Bool] do for any value type.
+//
+// Operations on another map use the ContainsFunc and MergeFunc of the receiver
+// and their results are bound to the same functions.
+//
+// As a field is already named Contains, membership is tested with Has.
+type With[K comparable, V any] struct {
+	M        map[K]V
+	Contains ContainsFunc[V]
+	Merge    MergeFunc[V]
+}
+
+// bind returns m bound to the ContainsFunc and MergeFunc of w.
+func (w With[K, V]) bind(m map[K]V) With[K, V] {
+	return With[K, V]{m, w.Contains, w.Merge}
+}
+
+// Has reports whether k is in the set.
+func (w With[K, V]) Has(k K) bool {
+	return Contains(w.M, k, w.Contains)
+}
+
+// Delete removes key and reports if it was present.
+func (w With[K, V]) Delete(key K) bool {
+	seen := w.Has(key)
+	delete(w.M, key)
+	return seen
+}
+
+func (w With[K, V]) Union(o map[K]V) With[K, V] {
+	return w.bind(Union(w.M, o, w.Contains, w.Merge))
+}
+
+func (w With[K, V]) Intersect(o map[K]V) With[K, V] {
+	return w.bind(Intersect(w.M, o, w.Contains, w.Merge))
+}
+
+func (w With[K, V]) Diff(o map[K]V) With[K, V] {
+	return w.bind(Diff(w.M, o, w.Contains))
+}
+
+func (w With[K, V]) SymDiff(o map[K]V) With[K, V] {
+	return w.bind(SymDiff(w.M, o, w.Contains))
+}
+
+// UnionWith adds the items of o to w.
+func (w With[K, V]) UnionWith(o map[K]V) {
+	UnionInto(w.M, o, w.Contains, w.Merge)
+}
+
+// IntersectWith removes the items of w that are not in o.
+func (w With[K, V]) IntersectWith(o map[K]V) {
+	IntersectInPlace(w.M, o, w.Contains, w.Merge)
+}
+
+// DiffWith removes the items of o from w.
+func (w With[K, V]) DiffWith(o map[K]V) {
+	DiffInPlace(w.M, o, w.Contains)
+}
+
+// SymDiffWith sets w to the symmetric difference of w and o.
+func (w With[K, V]) SymDiffWith(o map[K]V) {
+	SymDiffInPlace(w.M, o, w.Contains)
+}
+
+func (w With[K, V]) Equal(o map[K]V) bool {
+	return Equal(w.M, o, w.Contains)
+}
+
+func (w With[K, V]) Subset(o map[K]V) bool {
+	return Subset(w.M, o, w.Contains)
+}
+
+func (w With[K, V]) ProperSubset(o map[K]V) bool {
+	return ProperSubset(w.M, o, w.Contains)
+}
+
+func (w With[K, V]) Disjoint(o map[K]V) bool {
+	return Disjoint(w.M, o, w.Contains)
+}
+
+func (w With[K, V]) Compare(o map[K]V) Relation {
+	return Compare(w.M, o, w.Contains)
+}
+
+func (w With[K, V]) Len() int {
+	return Len(w.M, w.Contains)
+}
+
+func (w With[K, V]) Clone() With[K, V] {
+	return w.bind(Clone(w.M, w.Contains))
+}
+
+func (w With[K, V]) Keys() []K {
+	return Keys(w.M, w.Contains)
+}
+
+func (w With[K, V]) Values() []V {
+	return Values(w.M, w.Contains)
+}
+
+func (w With[K, V]) All() iter.Seq2[K, V] {
+	return All(w.M, w.Contains)
+}
+
+func (w With[K, V]) Purge() {
+	Purge(w.M, w.Contains)
+}